@@ -0,0 +1,208 @@
+package timeline
+
+import "strings"
+
+// AllenRelation defines the thirteen possible relations between two timeline entries in Allen's interval
+// algebra, each of which describes how a "subject" entry relates to a "reference" one
+type AllenRelation int
+
+const (
+	// AllenRelationBefore indicates that the subject entry ends before the reference one starts
+	AllenRelationBefore AllenRelation = iota
+	// AllenRelationMeets indicates that the subject entry ends when the reference one starts
+	AllenRelationMeets
+	// AllenRelationOverlaps indicates that the subject entry starts before the reference one and ends within it
+	AllenRelationOverlaps
+	// AllenRelationStarts indicates that the subject entry starts with the reference one and ends before it
+	AllenRelationStarts
+	// AllenRelationDuring indicates that the subject entry starts after the reference one and ends before it
+	AllenRelationDuring
+	// AllenRelationFinishes indicates that the subject entry starts after the reference one and ends with it
+	AllenRelationFinishes
+	// AllenRelationEquals indicates that the two entries represent the same span of time
+	AllenRelationEquals
+	// AllenRelationAfter indicates that the subject entry starts after the reference one ends
+	AllenRelationAfter
+	// AllenRelationMetBy indicates that the subject entry starts when the reference one ends
+	AllenRelationMetBy
+	// AllenRelationOverlappedBy indicates that the subject entry starts within the reference one and ends after it
+	AllenRelationOverlappedBy
+	// AllenRelationStartedBy indicates that the subject entry starts with the reference one and ends after it
+	AllenRelationStartedBy
+	// AllenRelationContains indicates that the subject entry starts before the reference one and ends after it
+	AllenRelationContains
+	// AllenRelationFinishedBy indicates that the subject entry starts before the reference one and ends with it
+	AllenRelationFinishedBy
+)
+
+// Relate returns the AllenRelation that describes how entry a relates to entry b, e.g. AllenRelationBefore
+// if a ends before b starts
+func Relate(a, b Entry) AllenRelation {
+	return configOf(Timeline{a}).Relate(a, b)
+}
+
+// Relate returns the AllenRelation that describes how entry a relates to entry b, according to the configured
+// Boundary
+func (c Config) Relate(a, b Entry) AllenRelation {
+	return relateSpans(c.spanOf(a), c.spanOf(b))
+}
+
+// relateSpans implements Relate() for spans
+func relateSpans(a, b span) AllenRelation {
+	switch {
+	case a.end.Before(b.start):
+		return AllenRelationBefore
+	case a.end.Equal(b.start):
+		return AllenRelationMeets
+	case a.start.After(b.end):
+		return AllenRelationAfter
+	case a.start.Equal(b.end):
+		return AllenRelationMetBy
+	}
+	// the spans overlap
+	switch {
+	case a.start.Before(b.start):
+		if a.end.Before(b.end) {
+			return AllenRelationOverlaps
+		}
+		if a.end.Equal(b.end) {
+			return AllenRelationFinishedBy
+		}
+		return AllenRelationContains
+	case a.start.Equal(b.start):
+		if a.end.Before(b.end) {
+			return AllenRelationStarts
+		}
+		if a.end.Equal(b.end) {
+			return AllenRelationEquals
+		}
+		return AllenRelationStartedBy
+	default:
+		if a.end.Before(b.end) {
+			return AllenRelationDuring
+		}
+		if a.end.Equal(b.end) {
+			return AllenRelationFinishes
+		}
+		return AllenRelationOverlappedBy
+	}
+}
+
+// Inverse returns the converse relation, i.e. the relation of b to a if v is the relation of a to b
+func (v AllenRelation) Inverse() AllenRelation {
+	m := map[AllenRelation]AllenRelation{
+		AllenRelationBefore:       AllenRelationAfter,
+		AllenRelationMeets:        AllenRelationMetBy,
+		AllenRelationOverlaps:     AllenRelationOverlappedBy,
+		AllenRelationStarts:       AllenRelationStartedBy,
+		AllenRelationDuring:       AllenRelationContains,
+		AllenRelationFinishes:     AllenRelationFinishedBy,
+		AllenRelationEquals:       AllenRelationEquals,
+		AllenRelationAfter:        AllenRelationBefore,
+		AllenRelationMetBy:        AllenRelationMeets,
+		AllenRelationOverlappedBy: AllenRelationOverlaps,
+		AllenRelationStartedBy:    AllenRelationStarts,
+		AllenRelationContains:     AllenRelationDuring,
+		AllenRelationFinishedBy:   AllenRelationFinishes,
+	}
+	if r, ok := m[v]; ok {
+		return r
+	}
+	return v
+}
+
+// IntersectionType maps the relation to the corresponding IntersectionType value, such that
+// Relate(a, b).IntersectionType() is equal to Intersect(b, a) (i.e. with b as the "reference" entry and a as the
+// "new" one)
+func (v AllenRelation) IntersectionType() IntersectionType {
+	switch v {
+	case AllenRelationMeets, AllenRelationMetBy:
+		return IntersectionTypeAdjacent
+	case AllenRelationOverlaps, AllenRelationFinishedBy:
+		return IntersectionTypeStartOverlap
+	case AllenRelationStarts, AllenRelationDuring, AllenRelationFinishes:
+		return IntersectionTypeWithin
+	case AllenRelationEquals:
+		return IntersectionTypeSame
+	case AllenRelationOverlappedBy, AllenRelationStartedBy:
+		return IntersectionTypeEndOverlap
+	case AllenRelationContains:
+		return IntersectionTypeCover
+	default:
+		return IntersectionTypeNone
+	}
+}
+
+// String implements fmt.Stringer for AllenRelation values
+func (v AllenRelation) String() string {
+	m := map[AllenRelation]string{
+		AllenRelationBefore:       "before",
+		AllenRelationMeets:        "meets",
+		AllenRelationOverlaps:     "overlaps",
+		AllenRelationStarts:       "starts",
+		AllenRelationDuring:       "during",
+		AllenRelationFinishes:     "finishes",
+		AllenRelationEquals:       "equals",
+		AllenRelationAfter:        "after",
+		AllenRelationMetBy:        "met-by",
+		AllenRelationOverlappedBy: "overlapped-by",
+		AllenRelationStartedBy:    "started-by",
+		AllenRelationContains:     "contains",
+		AllenRelationFinishedBy:   "finished-by",
+	}
+	if s, ok := m[v]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+// ParseAllenRelation parses the specified string into an AllenRelation enumeration value.
+//
+// If the string does not contain a valid AllenRelation string, AllenRelationBefore is returned.
+func ParseAllenRelation(s string) AllenRelation {
+	v, err := parseAllenRelationValue(s)
+	if err != nil {
+		return AllenRelationBefore
+	}
+	return v
+}
+
+// MarshalText implements encoding.TextMarshaler for AllenRelation values.
+//
+// The marshalled value is the result of calling .String() on the enum value.
+func (v AllenRelation) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for AllenRelation values.
+func (v *AllenRelation) UnmarshalText(p []byte) error {
+	r, err := parseAllenRelationValue(string(p))
+	if err != nil {
+		return err
+	}
+	*v = r
+	return nil
+}
+
+func parseAllenRelationValue(s string) (AllenRelation, error) {
+	m := map[string]AllenRelation{
+		"before":        AllenRelationBefore,
+		"meets":         AllenRelationMeets,
+		"overlaps":      AllenRelationOverlaps,
+		"starts":        AllenRelationStarts,
+		"during":        AllenRelationDuring,
+		"finishes":      AllenRelationFinishes,
+		"equals":        AllenRelationEquals,
+		"after":         AllenRelationAfter,
+		"met-by":        AllenRelationMetBy,
+		"overlapped-by": AllenRelationOverlappedBy,
+		"started-by":    AllenRelationStartedBy,
+		"contains":      AllenRelationContains,
+		"finished-by":   AllenRelationFinishedBy,
+	}
+	v, exists := m[strings.ToLower(s)]
+	if !exists {
+		return AllenRelationBefore, ErrInvalidAllenRelation
+	}
+	return v, nil
+}
@@ -0,0 +1,84 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestRelate(t *testing.T) {
+	ref := timeline.Must(timeline.ForDateRange(2000, time.January, 10, 2000, time.January, 20))
+	day := func(d int) time.Time { return time.Date(2000, time.January, d, 0, 0, 0, 0, time.UTC) }
+	cases := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected timeline.AllenRelation
+	}{
+		{"before", day(1), day(5), timeline.AllenRelationBefore},
+		{"meets", day(1), day(10), timeline.AllenRelationMeets},
+		{"overlaps", day(1), day(15), timeline.AllenRelationOverlaps},
+		{"starts", day(10), day(15), timeline.AllenRelationStarts},
+		{"during", day(12), day(15), timeline.AllenRelationDuring},
+		{"finishes", day(15), day(20), timeline.AllenRelationFinishes},
+		{"equals", day(10), day(20), timeline.AllenRelationEquals},
+		{"after", day(25), day(30), timeline.AllenRelationAfter},
+		{"met by", day(20), day(30), timeline.AllenRelationMetBy},
+		{"overlapped by", day(15), day(30), timeline.AllenRelationOverlappedBy},
+		{"started by", day(10), day(30), timeline.AllenRelationStartedBy},
+		{"contains", day(1), day(30), timeline.AllenRelationContains},
+		{"finished by", day(1), day(20), timeline.AllenRelationFinishedBy},
+		{"open-ended/overlapped by", day(15), time.Time{}, timeline.AllenRelationOverlappedBy},
+		{"no start/finished by", time.Time{}, day(20), timeline.AllenRelationFinishedBy},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			e := timeline.Must(timeline.NewEntry(tc.start, tc.end))
+			got := timeline.Relate(e, ref)
+			if got != tc.expected {
+				tt.Fatalf("Expected %q, got %q", tc.expected, got)
+			}
+			if inv := timeline.Relate(ref, e); inv != got.Inverse() {
+				tt.Errorf("Expected inverse %q, got %q", got.Inverse(), inv)
+			}
+			if it := timeline.Intersect(ref, e); it != got.IntersectionType() {
+				tt.Errorf("Expected IntersectionType %q, got %q", it, got.IntersectionType())
+			}
+		})
+	}
+}
+
+func TestConfigRelate(t *testing.T) {
+	var (
+		jan  = timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.January, 31))
+		feb  = timeline.Must(timeline.ForDateRange(2000, time.February, 1, 2000, time.February, 29))
+		days = timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}
+	)
+	if got := days.Relate(jan, feb); got != timeline.AllenRelationMeets {
+		t.Errorf("Expected %q, got %q", timeline.AllenRelationMeets, got)
+	}
+	if got := timeline.Relate(jan, feb); got != timeline.AllenRelationBefore {
+		t.Errorf("Expected %q, got %q", timeline.AllenRelationBefore, got)
+	}
+}
+
+func TestAllenRelationText(t *testing.T) {
+	for r := timeline.AllenRelationBefore; r <= timeline.AllenRelationFinishedBy; r++ {
+		if r.Inverse().Inverse() != r {
+			t.Errorf("Expected the inverse of the inverse of %q to be itself", r)
+		}
+		p, _ := r.MarshalText()
+		var got timeline.AllenRelation
+		if err := got.UnmarshalText(p); err != nil || got != r {
+			t.Errorf("Expected %q to round trip, got %q (%v)", r, got, err)
+		}
+		if got := timeline.ParseAllenRelation(r.String()); got != r {
+			t.Errorf("Expected ParseAllenRelation(%q) to return %q, got %q", r, r, got)
+		}
+	}
+	var r timeline.AllenRelation
+	if err := r.UnmarshalText([]byte("near")); err != timeline.ErrInvalidAllenRelation {
+		t.Errorf("Expected ErrInvalidAllenRelation, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package timeline
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	beginningOfTimeMtx sync.RWMutex
+	beginningOfTime    = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// BeginningOfTime returns the configured sentinel value that represents the earliest possible start date for
+// a timeline entry, which defaults to midnight UTC on 0000-01-01.
+//
+// If necessary, this value can be overridden by calling SetBeginningOfTime()
+func BeginningOfTime() time.Time {
+	beginningOfTimeMtx.RLock()
+	v := beginningOfTime
+	beginningOfTimeMtx.RUnlock()
+	return v
+}
+
+// SetBeginningOfTime assigns a custom sentinel value to represent the earliest possible start date for a
+// timeline entry.
+func SetBeginningOfTime(t time.Time) {
+	beginningOfTimeMtx.Lock()
+	beginningOfTime = t
+	beginningOfTimeMtx.Unlock()
+}
@@ -0,0 +1,138 @@
+package timeline
+
+import (
+	"sort"
+	"time"
+)
+
+// BitemporalFact represents a value that applies during a span of valid time, along with the span of recorded
+// (transaction) time during which it was believed to be true.  Recorded has no end date for facts that are
+// still believed to be true.
+type BitemporalFact[T any] struct {
+	Valid    Entry
+	Recorded Entry
+	Value    T
+}
+
+// Bitemporal stores facts along two time axes: the valid time during which a value applies and the recorded
+// time during which that was believed, so that questions such as "what did we believe on date X about the
+// value on date Y" can be answered.
+//
+// Facts are never overwritten.  When a new value is recorded for a span of valid time, the recorded time of any
+// facts that it supersedes is ended at the time of the change and the portions of their valid time that are
+// not superseded are recorded again as new facts.  Both axes are treated as half-open ranges, as for
+// ValueTimeline.
+//
+// The zero value is an empty Bitemporal that is ready to use.
+type Bitemporal[T any] struct {
+	facts  []BitemporalFact[T]
+	latest time.Time // time of the latest recorded change
+}
+
+// Record records that v applies during the span of valid time covered by e, as of the specified recorded time,
+// superseding any facts that were previously believed for that span.
+//
+// Changes must be recorded in chronological order, so ErrInvalidRecordedTime is returned if at is the zero
+// time or earlier than a previously recorded change.
+func (b *Bitemporal[T]) Record(e Entry, v T, at time.Time) error {
+	if err := b.supersede(e, at); err != nil {
+		return err
+	}
+	b.facts = append(b.facts, BitemporalFact[T]{
+		Valid:    e,
+		Recorded: halfOpen.entry(span{start: at, end: maxTime}),
+		Value:    v,
+	})
+	return nil
+}
+
+// Retract records that no value applies during the span of valid time covered by e, as of the specified
+// recorded time, superseding any facts that were previously believed for that span.
+//
+// As for Record(), ErrInvalidRecordedTime is returned if at is the zero time or earlier than a previously
+// recorded change.
+func (b *Bitemporal[T]) Retract(e Entry, at time.Time) error {
+	return b.supersede(e, at)
+}
+
+// supersede ends the recorded time of current facts that overlap the valid time covered by e and records the
+// portions of them that are not covered again
+func (b *Bitemporal[T]) supersede(e Entry, at time.Time) error {
+	if at.IsZero() || at.Before(b.latest) {
+		return ErrInvalidRecordedTime
+	}
+	b.latest = at
+	var (
+		vs    = halfOpen.spanOf(e)
+		cur   = halfOpen.entry(span{start: at, end: maxTime})
+		res   = b.facts[:0]
+		added []BitemporalFact[T]
+	)
+	for _, f := range b.facts {
+		fs := halfOpen.spanOf(f.Valid)
+		if _, hasEnd := f.Recorded.EndTime(); hasEnd || !fs.start.Before(vs.end) || !vs.start.Before(fs.end) {
+			// either no longer believed or not affected
+			res = append(res, f)
+			continue
+		}
+		if fs.start.Before(vs.start) {
+			added = append(added, BitemporalFact[T]{Valid: halfOpen.derive(span{start: fs.start, end: vs.start}, f.Valid), Recorded: cur, Value: f.Value})
+		}
+		if vs.end.Before(fs.end) {
+			added = append(added, BitemporalFact[T]{Valid: halfOpen.derive(span{start: vs.end, end: fs.end}, f.Valid), Recorded: cur, Value: f.Value})
+		}
+		// facts recorded at the same instant are replaced rather than being retained w/ an empty recorded time
+		if rst := f.Recorded.StartTime(); rst.Before(at) {
+			f.Recorded = halfOpen.entry(span{start: rst, end: at})
+			res = append(res, f)
+		}
+	}
+	b.facts = append(res, added...)
+	return nil
+}
+
+// AsOf returns the facts that were believed to be true at the specified recorded time, sorted by the start of
+// their valid time.  The valid times of the returned facts do not overlap.
+func (b *Bitemporal[T]) AsOf(recorded time.Time) []BitemporalFact[T] {
+	var res []BitemporalFact[T]
+	for _, f := range b.facts {
+		if rs := halfOpen.spanOf(f.Recorded); !rs.start.After(recorded) && rs.end.After(recorded) {
+			res = append(res, f)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return spanLess(halfOpen.spanOf(res[i].Valid), halfOpen.spanOf(res[j].Valid))
+	})
+	return res
+}
+
+// ValueAt returns the value that was believed, at the specified recorded time, to apply at the specified valid
+// time, along with a boolean value indicating whether or not any value was believed to apply
+func (b *Bitemporal[T]) ValueAt(valid, recorded time.Time) (T, bool) {
+	for _, f := range b.AsOf(recorded) {
+		if vs := halfOpen.spanOf(f.Valid); !vs.start.After(valid) && vs.end.After(valid) {
+			return f.Value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Timeline returns a normalized Timeline covering the valid time of every fact that was believed to be true at
+// the specified recorded time
+func (b *Bitemporal[T]) Timeline(recorded time.Time) Timeline {
+	facts := b.AsOf(recorded)
+	tl := make(Timeline, len(facts))
+	for i, f := range facts {
+		tl[i] = f.Valid
+	}
+	return halfOpen.Union(tl, nil)
+}
+
+// Facts returns a copy of every fact that has been recorded, including those that are no longer believed to be
+// true
+func (b *Bitemporal[T]) Facts() []BitemporalFact[T] {
+	res := make([]BitemporalFact[T], len(b.facts))
+	copy(res, b.facts)
+	return res
+}
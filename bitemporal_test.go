@@ -0,0 +1,72 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestBitemporal(t *testing.T) {
+	var (
+		b     timeline.Bitemporal[string]
+		day   = func(m time.Month, d int) time.Time { return time.Date(2000, m, d, 0, 0, 0, 0, time.UTC) }
+		rec1  = day(time.January, 1)
+		rec2  = day(time.February, 1)
+		rec3  = day(time.March, 1)
+		valid = timeline.Must(timeline.FromStartDate(2000, time.January, 1))
+	)
+	// initially recorded as "a" from Jan 1st onwards
+	if err := b.Record(valid, "a", rec1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// corrected on Feb 1st to "b" for the month of June
+	june := timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2000, time.July, 1))
+	if err := b.Record(june, "b", rec2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// retracted on Mar 1st from Dec 1st onwards
+	if err := b.Retract(timeline.Must(timeline.FromStartDate(2000, time.December, 1)), rec3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := b.Record(valid, "c", rec2); err != timeline.ErrInvalidRecordedTime {
+		t.Errorf("Expected ErrInvalidRecordedTime, got %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		valid    time.Time
+		recorded time.Time
+		expected string
+	}{
+		{"before first record", day(time.June, 15), day(time.January, 1).Add(-1), ""},
+		{"initial belief", day(time.June, 15), day(time.January, 15), "a"},
+		{"corrected belief", day(time.June, 15), rec2, "b"},
+		{"unaffected by correction", day(time.May, 15), rec2, "a"},
+		{"end of correction", day(time.July, 1), rec2, "a"},
+		{"before retraction", day(time.December, 15), day(time.February, 15), "a"},
+		{"after retraction", day(time.December, 15), rec3, ""},
+		{"unaffected by retraction", day(time.November, 30), rec3, "a"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			v, ok := b.ValueAt(tc.valid, tc.recorded)
+			if ok != (tc.expected != "") || v != tc.expected {
+				tt.Errorf("Expected %q, got %q (%v)", tc.expected, v, ok)
+			}
+		})
+	}
+
+	expected := timeline.Timeline{timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.December, 1))}
+	if tl := b.Timeline(rec3); !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+	if n := len(b.AsOf(rec3)); n != 3 {
+		t.Errorf("Expected 3 facts as of %s, got %d", rec3, n)
+	}
+	// the original fact is retained w/ its recorded time ended by the correction
+	f := b.Facts()[0]
+	if rt, hasEnd := f.Recorded.EndTime(); f.Value != "a" || !hasEnd || !rt.Equal(rec2) {
+		t.Errorf("Expected original fact to be recorded until %s, got %s", rec2, f.Recorded)
+	}
+}
@@ -0,0 +1,74 @@
+package timeline
+
+import "strings"
+
+// Boundary defines how the start and end times of timeline entries are interpreted when determining whether
+// entries contain a given time, intersect or should be combined
+type Boundary int
+
+const (
+	// BoundaryDefault indicates that an entry includes both its start and end time when checking whether it
+	// contains a given time, but that an entry ending at the same time another one starts is adjacent to
+	// (rather than overlapping) it
+	BoundaryDefault Boundary = iota
+	// BoundaryHalfOpen indicates that an entry includes its start time but not its end time, i.e. [start, end)
+	BoundaryHalfOpen
+	// BoundaryClosed indicates that an entry includes both its start and end time, i.e. [start, end], and that
+	// an entry ending at t is adjacent to one that starts at t plus the configured granularity (e.g. one day for
+	// date ranges)
+	BoundaryClosed
+)
+
+// String implements fmt.Stringer for Boundary values
+func (v Boundary) String() string {
+	m := map[Boundary]string{
+		BoundaryDefault:  "default",
+		BoundaryHalfOpen: "half-open",
+		BoundaryClosed:   "closed",
+	}
+	if s, ok := m[v]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+// ParseBoundary parses the specified string into a Boundary enumeration value.
+//
+// If the string does not contain a valid Boundary string, BoundaryDefault is returned.
+func ParseBoundary(s string) Boundary {
+	v, err := parseBoundaryValue(s)
+	if err != nil {
+		return BoundaryDefault
+	}
+	return v
+}
+
+// MarshalText implements encoding.TextMarshaler for Boundary values.
+//
+// The marshalled value is the result of calling .String() on the enum value.
+func (v Boundary) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for Boundary values.
+func (v *Boundary) UnmarshalText(p []byte) error {
+	r, err := parseBoundaryValue(string(p))
+	if err != nil {
+		return err
+	}
+	*v = r
+	return nil
+}
+
+func parseBoundaryValue(s string) (Boundary, error) {
+	m := map[string]Boundary{
+		"default":   BoundaryDefault,
+		"half-open": BoundaryHalfOpen,
+		"closed":    BoundaryClosed,
+	}
+	v, exists := m[strings.ToLower(s)]
+	if !exists {
+		return BoundaryDefault, ErrInvalidBoundary
+	}
+	return v, nil
+}
@@ -0,0 +1,84 @@
+package timeline
+
+import "sort"
+
+// ChangeSet describes the changes made to a timeline by AddWithChanges()
+type ChangeSet struct {
+	// Added contains the spans of time that are now covered by the timeline but were not previously
+	Added Timeline
+	// Replaced contains the existing entries that were merged, extended or removed
+	Replaced Timeline
+	// Result contains the entries that were inserted into the timeline or replaced existing ones
+	Result Timeline
+}
+
+// Changed returns a boolean value indicating whether or not the timeline was modified
+func (cs ChangeSet) Changed() bool {
+	return len(cs.Result) > 0
+}
+
+// AddWithChanges adds one or more entries to an existing timeline in the same way as Add(), and returns a
+// ChangeSet describing the modifications that were made
+func (tl *Timeline) AddWithChanges(entries ...Entry) (ChangeSet, error) {
+	return configOf(*tl, entries).AddWithChanges(tl, entries...)
+}
+
+// AddWithChanges adds one or more entries to an existing timeline in the same way as Add(), and returns a
+// ChangeSet describing the modifications that were made
+//
+// The entries are added one at a time, so an entry that results from adding one of them and is then replaced
+// by adding another is reported in neither Replaced nor Result.  As for Add(), a *ValidationError is returned
+// if any of the entries are invalid or the timeline is found not to be normalized, and the timeline is not
+// modified.
+func (c Config) AddWithChanges(tl *Timeline, entries ...Entry) (ChangeSet, error) {
+	var cs ChangeSet
+	if err := c.validateEntries(entries); err != nil {
+		return cs, err
+	}
+	if len(entries) > 1 {
+		// make sure that adding the first entries cannot succeed before an error is found
+		if err := c.Validate(*tl); err != nil {
+			return cs, err
+		}
+	}
+	for _, e := range entries {
+		if _, err := c.addEntry(tl, e, &cs); err != nil {
+			return ChangeSet{}, err
+		}
+	}
+	for _, v := range []Timeline{cs.Replaced, cs.Result} {
+		sort.Slice(v, func(i, j int) bool {
+			return spanLess(c.spanOf(v[i]), c.spanOf(v[j]))
+		})
+	}
+	return cs, nil
+}
+
+// record adds the result of adding a single entry to the change set, along with the existing entries that it
+// replaced.  It is a no-op if cs is nil.
+func (cs *ChangeSet) record(c Config, result Entry, replaced ...Entry) {
+	if cs == nil {
+		return
+	}
+	for _, r := range replaced {
+		rs := c.spanOf(r)
+		// entries resulting from earlier additions are intermediate, not replaced
+		if i := cs.indexOfResult(c, rs); i >= 0 {
+			cs.Result = append(cs.Result[:i], cs.Result[i+1:]...)
+			continue
+		}
+		cs.Replaced = append(cs.Replaced, r)
+	}
+	cs.Result = append(cs.Result, result)
+	cs.Added = c.Union(cs.Added, c.Difference(Timeline{result}, replaced))
+}
+
+// indexOfResult returns the index of the entry in Result that has the span s, or -1 if there is none
+func (cs *ChangeSet) indexOfResult(c Config, s span) int {
+	for i, e := range cs.Result {
+		if c.spanOf(e).equal(s) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,96 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestAddWithChanges(t *testing.T) {
+	existing := func() timeline.Timeline {
+		return timeline.New(
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)),
+			timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+		)
+	}
+	cases := []struct {
+		name     string
+		entries  []timeline.Entry
+		expected timeline.ChangeSet
+	}{
+		{
+			"already covered",
+			[]timeline.Entry{timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2000, time.July, 1))},
+			timeline.ChangeSet{},
+		},
+		{
+			"insert",
+			[]timeline.Entry{timeline.Must(timeline.ForDateRange(2001, time.June, 1, 2001, time.July, 1))},
+			timeline.ChangeSet{
+				Added:  timeline.Timeline{timeline.Must(timeline.ForDateRange(2001, time.June, 1, 2001, time.July, 1))},
+				Result: timeline.Timeline{timeline.Must(timeline.ForDateRange(2001, time.June, 1, 2001, time.July, 1))},
+			},
+		},
+		{
+			"extend start",
+			[]timeline.Entry{timeline.Must(timeline.ForDateRange(1999, time.January, 1, 2000, time.June, 1))},
+			timeline.ChangeSet{
+				Added:    timeline.Timeline{timeline.Must(timeline.ForDateRange(1999, time.January, 1, 2000, time.January, 1))},
+				Replaced: timeline.Timeline{timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1))},
+				Result:   timeline.Timeline{timeline.Must(timeline.ForDateRange(1999, time.January, 1, 2001, time.January, 1))},
+			},
+		},
+		{
+			"merge multiple",
+			[]timeline.Entry{timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2004, time.June, 1))},
+			timeline.ChangeSet{
+				Added: timeline.Timeline{
+					timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2002, time.January, 1)),
+					timeline.Must(timeline.ForDateRange(2003, time.January, 1, 2004, time.January, 1)),
+				},
+				Replaced: existing(),
+				Result:   timeline.Timeline{timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2005, time.January, 1))},
+			},
+		},
+		{
+			"intermediate result",
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2006, time.January, 1, 2007, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2005, time.January, 1, 2006, time.June, 1)),
+			},
+			timeline.ChangeSet{
+				Added:    timeline.Timeline{timeline.Must(timeline.ForDateRange(2005, time.January, 1, 2007, time.January, 1))},
+				Replaced: timeline.Timeline{timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1))},
+				Result:   timeline.Timeline{timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2007, time.January, 1))},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tl := existing()
+			cs, err := tl.AddWithChanges(tc.entries...)
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if cs.Changed() != tc.expected.Changed() {
+				tt.Errorf("Expected Changed() to be %v", tc.expected.Changed())
+			}
+			if !testIsSameTimeline(cs.Added, tc.expected.Added) {
+				tt.Errorf("Expected added:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected.Added), printTimeline(cs.Added))
+			}
+			if !testIsSameTimeline(cs.Replaced, tc.expected.Replaced) {
+				tt.Errorf("Expected replaced:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected.Replaced), printTimeline(cs.Replaced))
+			}
+			if !testIsSameTimeline(cs.Result, tc.expected.Result) {
+				tt.Errorf("Expected result:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected.Result), printTimeline(cs.Result))
+			}
+			expected := existing()
+			expected.Add(tc.entries...)
+			if !testIsSameTimeline(tl, expected) {
+				tt.Errorf("Expected timeline:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+			}
+		})
+	}
+}
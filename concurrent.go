@@ -0,0 +1,121 @@
+package timeline
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ConcurrentTimeline wraps a Timeline so that it can be safely shared between goroutines.
+//
+// Mutations are applied to a copy of the current timeline, which then replaces it (i.e. copy-on-write), so
+// readers always see a consistent snapshot and never block, or are blocked by, writers.  Writers are
+// serialized with respect to each other.
+//
+// The zero value is an empty ConcurrentTimeline that uses the zero Config.  A ConcurrentTimeline must not be
+// copied after first use.
+type ConcurrentTimeline struct {
+	cfg  Config
+	mu   sync.Mutex   // serializes writers
+	snap atomic.Value // current Timeline, which is never modified once stored
+}
+
+// NewConcurrent returns a new ConcurrentTimeline containing the specified entries
+func NewConcurrent(entries ...Entry) *ConcurrentTimeline {
+	return configOf(entries).NewConcurrent(entries...)
+}
+
+// NewConcurrent returns a new ConcurrentTimeline containing the specified entries, which uses the Config for
+// all operations
+func (c Config) NewConcurrent(entries ...Entry) *ConcurrentTimeline {
+	ct := &ConcurrentTimeline{cfg: c}
+	ct.snap.Store(c.New(entries...))
+	return ct
+}
+
+// Snapshot returns the current state of the timeline.
+//
+// The returned timeline is shared with other readers and must not be modified, use Clone() on it first if
+// necessary.  It is not affected by subsequent changes to the ConcurrentTimeline.
+func (ct *ConcurrentTimeline) Snapshot() Timeline {
+	tl, _ := ct.snap.Load().(Timeline)
+	return tl
+}
+
+// Add adds one or more entries to the timeline and returns a boolean value indicating whether or not the
+// timeline was modified, or a *ValidationError if any of the entries are invalid
+func (ct *ConcurrentTimeline) Add(entries ...Entry) (bool, error) {
+	var err error
+	updated := ct.Update(func(tl *Timeline) bool {
+		var ok bool
+		ok, err = ct.cfg.Add(tl, entries...)
+		return ok
+	})
+	return updated, err
+}
+
+// Remove removes the spans of time covered by one or more entries from the timeline and returns a boolean
+// value indicating whether or not the timeline was modified, or a *ValidationError if any of the entries are
+// invalid
+func (ct *ConcurrentTimeline) Remove(entries ...Entry) (bool, error) {
+	var err error
+	updated := ct.Update(func(tl *Timeline) bool {
+		var ok bool
+		ok, err = ct.cfg.Remove(tl, entries...)
+		return ok
+	})
+	return updated, err
+}
+
+// Replace replaces the contents of the timeline with the specified entries, e.g. after reloading them
+func (ct *ConcurrentTimeline) Replace(entries ...Entry) {
+	tl := ct.cfg.New(entries...)
+	ct.mu.Lock()
+	ct.snap.Store(tl)
+	ct.mu.Unlock()
+}
+
+// Update calls fn with a copy of the current timeline and, if fn returns true, replaces the timeline with the
+// modified copy.  The timeline is expected to remain normalized.
+//
+// Calls to Update() (and the other mutation methods) are serialized, so fn should not block.
+func (ct *ConcurrentTimeline) Update(fn func(tl *Timeline) bool) bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	tl := ct.Snapshot().Clone()
+	if !fn(&tl) {
+		return false
+	}
+	ct.snap.Store(tl)
+	return true
+}
+
+// Contains determines whether or not the specified time falls within one of the timeline entries and, if it
+// does, returns the start and end of the entry
+func (ct *ConcurrentTimeline) Contains(t time.Time) (bool, time.Time, time.Time) {
+	return ct.cfg.Contains(ct.Snapshot(), t)
+}
+
+// Len returns the number of entries in the timeline
+func (ct *ConcurrentTimeline) Len() int {
+	return len(ct.Snapshot())
+}
+
+// Range calls fn for each entry of a snapshot of the timeline, in chronological order, until fn returns false
+func (ct *ConcurrentTimeline) Range(fn func(e Entry) bool) {
+	for _, e := range ct.Snapshot() {
+		if !fn(e) {
+			return
+		}
+	}
+}
+
+// Clone returns a copy of the timeline that can be modified independently of it
+func (tl Timeline) Clone() Timeline {
+	if tl == nil {
+		return nil
+	}
+	res := make(Timeline, len(tl))
+	copy(res, tl)
+	return res
+}
@@ -0,0 +1,72 @@
+package timeline_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestConcurrentTimeline(t *testing.T) {
+	var (
+		ct   timeline.ConcurrentTimeline
+		wg   sync.WaitGroup
+		base = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+		n    = 200
+	)
+	snap := ct.Snapshot()
+	// readers
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				ct.Contains(base.AddDate(0, 0, i))
+				var prev timeline.Entry
+				ct.Range(func(e timeline.Entry) bool {
+					if prev != nil && !prev.StartTime().Before(e.StartTime()) {
+						t.Errorf("Snapshot is not sorted: %s, %s", prev, e)
+					}
+					prev = e
+					return true
+				})
+			}
+		}()
+	}
+	// writers adding every other day
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < n; i += 2 {
+				st := base.AddDate(0, 0, 2*i)
+				ct.Add(timeline.Must(timeline.NewEntry(st, st.AddDate(0, 0, 1))))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(snap) != 0 {
+		t.Errorf("Expected initial snapshot to remain empty, got %d entries", len(snap))
+	}
+	if ct.Len() != n {
+		t.Fatalf("Expected %d entries, got %d", n, ct.Len())
+	}
+	if ok, _, _ := ct.Contains(base.AddDate(0, 0, 2).Add(time.Hour)); !ok {
+		t.Errorf("Expected %s to be contained", base.AddDate(0, 0, 2).Add(time.Hour))
+	}
+
+	snap = ct.Snapshot()
+	if ok, _ := ct.Remove(timeline.Must(timeline.NewEntry(base, base.AddDate(1, 0, 0)))); !ok {
+		t.Errorf("Expected Remove() to modify the timeline")
+	}
+	if len(snap) != n || ct.Len() >= n {
+		t.Errorf("Expected snapshot to be unaffected by Remove(), got %d/%d entries", len(snap), ct.Len())
+	}
+
+	ct.Replace(timeline.Must(timeline.FromStartDate(2000, time.January, 1)))
+	if ct.Len() != 1 {
+		t.Errorf("Expected a single entry after Replace(), got %d", ct.Len())
+	}
+}
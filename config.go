@@ -0,0 +1,303 @@
+package timeline
+
+import (
+	"sort"
+	"time"
+)
+
+// Config defines the rules used to create, compare, combine and query timeline entries, so that Contains(),
+// Intersect() and the merging of entries by Add() and Normalize() all agree on how the start and end of an
+// entry are interpreted.
+//
+// The entries created by a Config record it, and the methods of Timeline, along with the package level functions
+// such as Intersect() and NewIndex(), use the Config that created the first entry of the timeline (or of the
+// entries) that they are called with.  Entries created by the package level functions such as NewEntry(), and
+// custom Entry implementations, use the zero Config, which takes its sentinel values from the package level
+// EndOfTime() and BeginningOfTime() settings.  To use different rules for a timeline, build its entries with a
+// Config, e.g.:
+//
+//	days := timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}
+//	tl := days.New(timeline.Must(days.ForDateRange(2000, time.January, 1, 2000, time.January, 31)))
+//	tl.Add(entries...)
+//	ok, _, _ := tl.Contains(t)
+//
+// The equivalent methods of Config (e.g. days.Add(&tl, entries...)) apply the Config regardless of how the
+// entries were created.
+type Config struct {
+	// EndOfTime is the sentinel value returned as the end time of entries without an end date.  Defaults to
+	// EndOfTime().
+	EndOfTime time.Time
+	// BeginningOfTime is the earliest start date used by Complement().  Defaults to BeginningOfTime().
+	BeginningOfTime time.Time
+	// Boundary defines whether or not the end time of an entry is included in it
+	Boundary Boundary
+	// Granularity defines the smallest unit of time for BoundaryClosed entries, so that an entry ending at t is
+	// adjacent to one starting at t + Granularity (e.g. 24 * time.Hour for date ranges).  Defaults to one
+	// nanosecond, the resolution of time.Time.
+	Granularity time.Duration
+	// KeepAdjacent indicates that adjacent entries should be kept separate rather than being combined into a
+	// single entry
+	KeepAdjacent bool
+	// Location is the time zone used by FromStartDate() and ForDateRange().  Defaults to UTC.
+	Location *time.Location
+}
+
+// configOf returns the Config that created the first entry of the first non-empty timeline, or the zero Config if
+// that entry was not created by a Config (e.g. a custom Entry implementation)
+func configOf(tls ...Timeline) Config {
+	for _, tl := range tls {
+		if len(tl) > 0 {
+			e, _ := tl[0].(entry)
+			return e.cfg
+		}
+	}
+	return Config{}
+}
+
+// endOfTime returns the configured EndOfTime sentinel, or the package level default if none was configured
+func (c Config) endOfTime() time.Time {
+	if c.EndOfTime.IsZero() {
+		return EndOfTime()
+	}
+	return c.EndOfTime
+}
+
+// beginningOfTime returns the configured BeginningOfTime sentinel, or the package level default if none was
+// configured
+func (c Config) beginningOfTime() time.Time {
+	if c.BeginningOfTime.IsZero() {
+		return BeginningOfTime()
+	}
+	return c.BeginningOfTime
+}
+
+// location returns the configured Location, or UTC if none was configured
+func (c Config) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+// granularity returns the configured granularity, or one nanosecond if none was configured
+func (c Config) granularity() time.Duration {
+	if c.Granularity > 0 {
+		return c.Granularity
+	}
+	return time.Nanosecond
+}
+
+// Contains determines whether or not the specified time falls within one of the timeline entries and, if it
+// does, returns the start and end of the entry
+//
+// The timeline is expected to be normalized, which allows the entry to be located with a binary search.  If any
+// of the entries visited by the search are found to be invalid or out of order, every entry is checked instead
+// and any invalid entries are ignored.
+func (c Config) Contains(tl Timeline, t time.Time) (bool, time.Time, time.Time) {
+	if t.IsZero() {
+		return false, time.Time{}, time.Time{}
+	}
+	// the end of the entry's span is exclusive, except for BoundaryDefault
+	exclusive := c.Boundary != BoundaryDefault
+	i, err := c.search(tl, t, exclusive)
+	if err != nil {
+		return c.containsLinear(tl, t, exclusive)
+	}
+	if i < len(tl) {
+		e := tl[i]
+		if !c.spanOf(e).start.After(t) {
+			ed, _ := e.EndTime()
+			return true, e.StartTime(), ed
+		}
+	}
+	return false, time.Time{}, time.Time{}
+}
+
+// containsLinear implements Contains() by checking every valid entry in turn, for timelines that are not
+// normalized
+func (c Config) containsLinear(tl Timeline, t time.Time, exclusive bool) (bool, time.Time, time.Time) {
+	for _, e := range tl {
+		if c.validateEntry(e) != nil {
+			continue
+		}
+		s := c.spanOf(e)
+		if !s.start.After(t) && (s.end.After(t) || (!exclusive && s.end.Equal(t))) {
+			ed, _ := e.EndTime()
+			return true, e.StartTime(), ed
+		}
+	}
+	return false, time.Time{}, time.Time{}
+}
+
+// search returns the index of the first timeline entry whose span ends at or after t (or strictly after t,
+// if exclusive is true), or len(tl) if there is no such entry.  Since the entries of a normalized timeline do
+// not overlap, they are sorted by end time as well as start time and a binary search can be used.
+//
+// A *ValidationError is returned if any of the entries visited by the search are found not to be normalized.
+func (c Config) search(tl Timeline, t time.Time, exclusive bool) (int, error) {
+	var err error
+	i := sort.Search(len(tl), func(i int) bool {
+		s, serr := c.spanAt(tl, i)
+		if serr != nil {
+			if err == nil {
+				err = serr
+			}
+			return true
+		}
+		if exclusive {
+			return s.end.After(t)
+		}
+		return !s.end.Before(t)
+	})
+	return i, err
+}
+
+// Intersect compares two Entry items and returns an IntersectionType value that indicates how the second
+// "new" time span intersects with the first "reference" one
+func (c Config) Intersect(refEntry, newEntry Entry) IntersectionType {
+	return intersectSpans(c.spanOf(refEntry), c.spanOf(newEntry))
+}
+
+// span is the internal representation of an entry's range as a half-open interval [start, end), regardless of
+// the configured Boundary.  An entry without an end is assigned maxTime rather than the EndOfTime sentinel, so
+// that it cannot be confused with an entry that genuinely ends at that time, and an entry without a start is
+// assigned minTime.
+type span struct {
+	start time.Time
+	end   time.Time
+}
+
+// unixToInternal is the number of seconds between the zero time.Time (year 1) and the Unix epoch
+const unixToInternal int64 = (1969*365 + 1969/4 - 1969/100 + 1969/400) * 24 * 60 * 60
+
+// minTime is the earliest time that can be represented by time.Unix(), which is used as the start of the span of
+// an entry without a start date
+var minTime = time.Unix(-1<<63, 0)
+
+// maxTime is the latest time that can be represented by time.Time, which is used as the end of the span of an
+// entry without an end date
+var maxTime = time.Unix(1<<63-1-unixToInternal, 999999999)
+
+// spanOf returns the span of e according to the configured Boundary
+func (c Config) spanOf(e Entry) span {
+	start := e.StartTime()
+	if start.IsZero() {
+		start = minTime
+	}
+	end, hasEnd := e.EndTime()
+	switch {
+	case !hasEnd:
+		end = maxTime
+	case c.Boundary == BoundaryClosed:
+		end = end.Add(c.granularity())
+	}
+	return span{start: start, end: end}
+}
+
+// entry converts s back into an Entry according to the configured Boundary, which has no start date if s starts
+// at minTime and no end date if s ends at maxTime
+func (c Config) entry(s span) Entry {
+	e := entry{cfg: c}
+	if !s.start.Equal(minTime) {
+		e.start = s.start
+	}
+	switch {
+	case s.end.Equal(maxTime):
+		e.end, e.open = c.endOfTime(), true
+	case c.Boundary == BoundaryClosed:
+		e.end = s.end.Add(-c.granularity())
+	default:
+		e.end = s.end
+	}
+	return e
+}
+
+// derive converts s into an Entry that was derived from one or more source entries.  If any of the sources
+// implement Merger, the first of them is used to construct the entry, otherwise it is constructed as for entry().
+func (c Config) derive(s span, sources ...Entry) Entry {
+	e := c.entry(s)
+	for i, src := range sources {
+		m, ok := src.(Merger)
+		if !ok {
+			continue
+		}
+		end, hasEnd := e.EndTime()
+		if !hasEnd {
+			end = time.Time{}
+		}
+		others := make([]Entry, 0, len(sources)-1)
+		others = append(append(others, sources[:i]...), sources[i+1:]...)
+		return m.Merge(c, e.StartTime(), end, others...)
+	}
+	return e
+}
+
+// mergeType returns the IntersectionType of two spans for the purpose of combining them, where adjacent spans
+// are treated as not intersecting if KeepAdjacent is set
+func (c Config) mergeType(refSpan, newSpan span) IntersectionType {
+	itype := intersectSpans(refSpan, newSpan)
+	if itype == IntersectionTypeAdjacent && c.KeepAdjacent {
+		return IntersectionTypeNone
+	}
+	return itype
+}
+
+// equal reports whether s and other represent the same span of time
+func (s span) equal(other span) bool {
+	return s.start.Equal(other.start) && s.end.Equal(other.end)
+}
+
+// intersectSpans implements Intersect() for spans
+func intersectSpans(refSpan, newSpan span) IntersectionType {
+	// newStart < refStart
+	if newSpan.start.Before(refSpan.start) {
+		if newSpan.end.Before(refSpan.start) {
+			return IntersectionTypeNone
+		}
+		if newSpan.end.Equal(refSpan.start) {
+			return IntersectionTypeAdjacent
+		}
+		if newSpan.end.After(refSpan.end) {
+			return IntersectionTypeCover
+		}
+		return IntersectionTypeStartOverlap
+	}
+	// newStart > refStart
+	if newSpan.start.After(refSpan.start) {
+		if newSpan.start.After(refSpan.end) {
+			return IntersectionTypeNone
+		}
+		if newSpan.start.Equal(refSpan.end) {
+			return IntersectionTypeAdjacent
+		}
+		if !newSpan.end.After(refSpan.end) {
+			return IntersectionTypeWithin
+		}
+		return IntersectionTypeEndOverlap
+	}
+	// newStart == refStart
+	if newSpan.end.Before(refSpan.end) {
+		return IntersectionTypeWithin
+	}
+	if newSpan.end.After(refSpan.end) {
+		return IntersectionTypeEndOverlap
+	}
+	// newStart == refStart && newEnd == refEnd
+	return IntersectionTypeSame
+}
+
+// laterOf returns the later of t1 and t2
+func laterOf(t1, t2 time.Time) time.Time {
+	if t2.After(t1) {
+		return t2
+	}
+	return t1
+}
+
+// earlierOf returns the earlier of t1 and t2
+func earlierOf(t1, t2 time.Time) time.Time {
+	if t2.Before(t1) {
+		return t2
+	}
+	return t1
+}
@@ -0,0 +1,217 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestConfigContains(t *testing.T) {
+	tl := timeline.New(
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.January, 31)),
+	)
+	cases := []struct {
+		name     string
+		config   timeline.Config
+		value    time.Time
+		expected bool
+	}{
+		{"default/start", timeline.Config{}, time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), true},
+		{"default/end", timeline.Config{}, time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC), true},
+		{"default/after end", timeline.Config{}, time.Date(2000, time.January, 31, 0, 0, 0, 1, time.UTC), false},
+		{"half-open/start", timeline.Config{Boundary: timeline.BoundaryHalfOpen}, time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), true},
+		{"half-open/before end", timeline.Config{Boundary: timeline.BoundaryHalfOpen}, time.Date(2000, time.January, 30, 23, 0, 0, 0, time.UTC), true},
+		{"half-open/end", timeline.Config{Boundary: timeline.BoundaryHalfOpen}, time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC), false},
+		{"closed/end", timeline.Config{Boundary: timeline.BoundaryClosed}, time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC), true},
+		{"closed/after end", timeline.Config{Boundary: timeline.BoundaryClosed}, time.Date(2000, time.January, 31, 0, 0, 0, 1, time.UTC), false},
+		{"closed days/within end day", timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}, time.Date(2000, time.January, 31, 12, 0, 0, 0, time.UTC), true},
+		{"closed days/after end day", timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}, time.Date(2000, time.February, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			if got, _, _ := tc.config.Contains(tl, tc.value); got != tc.expected {
+				tt.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestConfigIntersect(t *testing.T) {
+	var (
+		jan = timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.January, 31))
+		feb = timeline.Must(timeline.ForDateRange(2000, time.February, 1, 2000, time.February, 29))
+		end = timeline.Must(timeline.ForDateRange(2000, time.January, 31, 2000, time.February, 29))
+	)
+	cases := []struct {
+		name     string
+		config   timeline.Config
+		ref, new timeline.Entry
+		expected timeline.IntersectionType
+	}{
+		{"default/touching", timeline.Config{}, jan, end, timeline.IntersectionTypeAdjacent},
+		{"default/next day", timeline.Config{}, jan, feb, timeline.IntersectionTypeNone},
+		{"half-open/touching", timeline.Config{Boundary: timeline.BoundaryHalfOpen}, jan, end, timeline.IntersectionTypeAdjacent},
+		{"closed/touching", timeline.Config{Boundary: timeline.BoundaryClosed}, jan, end, timeline.IntersectionTypeEndOverlap},
+		{"closed/next day", timeline.Config{Boundary: timeline.BoundaryClosed}, jan, feb, timeline.IntersectionTypeNone},
+		{"closed days/next day", timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}, jan, feb, timeline.IntersectionTypeAdjacent},
+		{"closed days/touching", timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}, jan, end, timeline.IntersectionTypeEndOverlap},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			if got := tc.config.Intersect(tc.ref, tc.new); got != tc.expected {
+				tt.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestConfigClosedDays(t *testing.T) {
+	days := timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}
+	tl := days.New(
+		timeline.Must(timeline.ForDateRange(2000, time.February, 1, 2000, time.February, 29)),
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.January, 31)),
+		timeline.Must(timeline.ForDateRange(2000, time.April, 1, 2000, time.April, 30)),
+	)
+	expected := timeline.Timeline{
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.February, 29)),
+		timeline.Must(timeline.ForDateRange(2000, time.April, 1, 2000, time.April, 30)),
+	}
+	if !testIsSameTimeline(tl, expected) {
+		t.Fatalf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+
+	gaps := days.Gaps(tl, timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.December, 31)))
+	expected = timeline.Timeline{
+		timeline.Must(timeline.ForDateRange(2000, time.March, 1, 2000, time.March, 31)),
+		timeline.Must(timeline.ForDateRange(2000, time.May, 1, 2000, time.December, 31)),
+	}
+	if !testIsSameTimeline(gaps, expected) {
+		t.Errorf("Expected gaps:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(gaps))
+	}
+
+	days.Remove(&tl, timeline.Must(timeline.ForDateRange(2000, time.January, 10, 2000, time.January, 20)))
+	expected = timeline.Timeline{
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.January, 9)),
+		timeline.Must(timeline.ForDateRange(2000, time.January, 21, 2000, time.February, 29)),
+		timeline.Must(timeline.ForDateRange(2000, time.April, 1, 2000, time.April, 30)),
+	}
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected after remove:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+}
+
+func TestBoundaryText(t *testing.T) {
+	for _, b := range []timeline.Boundary{timeline.BoundaryDefault, timeline.BoundaryHalfOpen, timeline.BoundaryClosed} {
+		p, _ := b.MarshalText()
+		var got timeline.Boundary
+		if err := got.UnmarshalText(p); err != nil || got != b {
+			t.Errorf("Expected %q to round trip, got %q (%v)", b, got, err)
+		}
+	}
+	var b timeline.Boundary
+	if err := b.UnmarshalText([]byte("open")); err != timeline.ErrInvalidBoundary {
+		t.Errorf("Expected ErrInvalidBoundary, got %v", err)
+	}
+}
+
+func TestConfigEndOfTime(t *testing.T) {
+	infinity := timeline.Config{EndOfTime: time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)}
+	e := timeline.Must(infinity.FromStartDate(2000, time.January, 1))
+	if et, hasEnd := e.EndTime(); hasEnd || !et.Equal(infinity.EndOfTime) {
+		t.Errorf("Expected no end date at %s, got %s (%v)", infinity.EndOfTime, et, hasEnd)
+	}
+
+	// entries without an end date are extended to the configured sentinel when combined
+	tl := infinity.New(
+		e,
+		timeline.Must(infinity.ForDateRange(1999, time.January, 1, 2000, time.June, 1)),
+	)
+	expected := timeline.Timeline{timeline.Must(infinity.FromStartDate(1999, time.January, 1))}
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+}
+
+func TestConfigKeepAdjacent(t *testing.T) {
+	var (
+		jan  = timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.February, 1))
+		feb  = timeline.Must(timeline.ForDateRange(2000, time.February, 1, 2000, time.March, 1))
+		mar  = timeline.Must(timeline.ForDateRange(2000, time.March, 1, 2000, time.April, 1))
+		keep = timeline.Config{KeepAdjacent: true}
+	)
+	expected := timeline.Timeline{jan, feb, mar}
+
+	tl := keep.New(mar, jan, feb)
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected New:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+
+	tl = timeline.Timeline{}
+	for _, e := range []timeline.Entry{mar, jan, feb} {
+		keep.Add(&tl, e)
+	}
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected Add:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+
+	// overlapping entries are still combined
+	keep.Add(&tl, timeline.Must(timeline.ForDateRange(2000, time.January, 15, 2000, time.February, 15)))
+	expected = timeline.Timeline{
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.March, 1)),
+		mar,
+	}
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected overlap:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+}
+
+func TestConfigLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	e := timeline.Must(timeline.Config{Location: loc}.ForDateRange(2000, time.January, 1, 2000, time.January, 31))
+	if expected := time.Date(2000, time.January, 1, 0, 0, 0, 0, loc); !e.StartTime().Equal(expected) {
+		t.Errorf("Expected start %s, got %s", expected, e.StartTime())
+	}
+}
+
+func TestConfigClosedSingleInstant(t *testing.T) {
+	st := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := timeline.NewEntry(st, st); err != timeline.ErrInvalidTimelineOrder {
+		t.Errorf("Expected ErrInvalidTimelineOrder, got %v", err)
+	}
+	if _, err := (timeline.Config{Boundary: timeline.BoundaryClosed}).NewEntry(st, st); err != nil {
+		t.Errorf("Expected no error for a closed entry, got %v", err)
+	}
+}
+
+func TestTimelineUsesEntryConfig(t *testing.T) {
+	days := timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}
+	tl := days.New(timeline.Must(days.ForDateRange(2000, time.January, 1, 2000, time.January, 10)))
+	if ok, _, _ := tl.Contains(time.Date(2000, time.January, 10, 12, 0, 0, 0, time.UTC)); !ok {
+		t.Errorf("Expected the timeline to contain the end day")
+	}
+	if _, err := tl.Add(timeline.Must(days.ForDateRange(2000, time.January, 11, 2000, time.January, 20))); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := timeline.Timeline{timeline.Must(days.ForDateRange(2000, time.January, 1, 2000, time.January, 20))}
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+	if it := timeline.Intersect(tl[0], timeline.Must(days.ForDateRange(2000, time.January, 21, 2000, time.January, 31))); it != timeline.IntersectionTypeAdjacent {
+		t.Errorf("Expected the entries to be adjacent, got %v", it)
+	}
+
+	// an empty timeline takes the Config of the entries that are added to it
+	keep := timeline.Config{KeepAdjacent: true}
+	var adjacent timeline.Timeline
+	_, err := adjacent.Add(
+		timeline.Must(keep.ForDateRange(2000, time.January, 1, 2000, time.January, 10)),
+		timeline.Must(keep.ForDateRange(2000, time.January, 10, 2000, time.January, 20)),
+	)
+	if err != nil || len(adjacent) != 2 {
+		t.Errorf("Expected the adjacent entries to be kept separate, got %s (%v)", printTimeline(adjacent), err)
+	}
+	if err := adjacent.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
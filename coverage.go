@@ -0,0 +1,175 @@
+package timeline
+
+import (
+	"sort"
+	"time"
+)
+
+// CoverageSegment represents a span of time within a Coverage along with the number of entries that cover it
+type CoverageSegment struct {
+	Entry
+	Count int
+}
+
+// Coverage records how many of a set of (possibly overlapping) entries cover each span of time, e.g. the number
+// of concurrent sessions or of staff on shift.
+//
+// The entries are divided into non-overlapping segments, sorted by start time, such that the number of entries
+// covering every instant of a segment is the same.  Only spans of time covered by at least one entry have a
+// segment, and adjacent segments always have different counts.  As for ValueTimeline, segments are treated as
+// half-open ranges so that exactly one count applies at the boundary between two adjacent segments.
+//
+// CountAt() and Max() apply the configured Boundary, so that with BoundaryDefault an entry is also counted at its
+// end time, as for Contains().
+type Coverage struct {
+	cfg      Config
+	segments []coverageSpan
+	ends     []time.Time // the sorted end times of the entries, for BoundaryDefault only
+}
+
+// coverageSpan is the internal representation of a CoverageSegment
+type coverageSpan struct {
+	span  span
+	count int
+}
+
+// NewCoverage returns a new Coverage for the specified entries
+func NewCoverage(entries ...Entry) *Coverage {
+	return configOf(entries).NewCoverage(entries...)
+}
+
+// NewCoverage returns a new Coverage for the specified entries, which are interpreted according to the Config.
+// Any invalid entries are ignored, as for New().
+//
+// The segments are computed with a sweep line over the start and end of every entry, in O(n log n).
+func (c Config) NewCoverage(entries ...Entry) *Coverage {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+	events := make([]event, 0, 2*len(entries))
+	for _, e := range entries {
+		if c.validateEntry(e) != nil {
+			continue
+		}
+		s := c.spanOf(e)
+		events = append(events, event{at: s.start, delta: 1}, event{at: s.end, delta: -1})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].at.Before(events[j].at)
+	})
+	cv := &Coverage{cfg: c}
+	if c.Boundary == BoundaryDefault {
+		for _, ev := range events {
+			if ev.delta < 0 && !ev.at.Equal(maxTime) {
+				cv.ends = append(cv.ends, ev.at)
+			}
+		}
+	}
+	var (
+		count int
+		start time.Time
+	)
+	for i := 0; i < len(events); {
+		// apply all of the events at the same time together
+		at, prev := events[i].at, count
+		for ; i < len(events) && events[i].at.Equal(at); i++ {
+			count += events[i].delta
+		}
+		if count == prev {
+			continue
+		}
+		if prev > 0 {
+			cv.segments = append(cv.segments, coverageSpan{span: span{start: start, end: at}, count: prev})
+		}
+		start = at
+	}
+	return cv
+}
+
+// Segments returns the segments of the coverage, sorted by start time
+func (cv *Coverage) Segments() []CoverageSegment {
+	return cv.AtLeast(1)
+}
+
+// AtLeast returns the segments that are covered by at least k entries, sorted by start time
+func (cv *Coverage) AtLeast(k int) []CoverageSegment {
+	var res []CoverageSegment
+	for _, cs := range cv.segments {
+		if cs.count >= k {
+			res = append(res, CoverageSegment{Entry: cv.cfg.entry(cs.span), Count: cs.count})
+		}
+	}
+	return res
+}
+
+// CountAt returns the number of entries that cover the specified time, according to the configured Boundary
+func (cv *Coverage) CountAt(t time.Time) int {
+	count := 0
+	i := sort.Search(len(cv.segments), func(i int) bool {
+		return cv.segments[i].span.end.After(t)
+	})
+	if i < len(cv.segments) && !cv.segments[i].span.start.After(t) {
+		count = cv.segments[i].count
+	}
+	// entries include their end time for BoundaryDefault, so also count those that end at t
+	return count + cv.endingAt(t)
+}
+
+// endingAt returns the number of entries whose end is included in them and is equal to t
+func (cv *Coverage) endingAt(t time.Time) int {
+	i := sort.Search(len(cv.ends), func(i int) bool {
+		return !cv.ends[i].Before(t)
+	})
+	j := i
+	for j < len(cv.ends) && cv.ends[j].Equal(t) {
+		j++
+	}
+	return j - i
+}
+
+// Max returns the maximum number of entries that cover any instant, according to the configured Boundary
+func (cv *Coverage) Max() int {
+	max := 0
+	for _, cs := range cv.segments {
+		if cs.count > max {
+			max = cs.count
+		}
+	}
+	// with BoundaryDefault, the count at the end of an entry may be higher than that of either segment around it
+	for i, t := range cv.ends {
+		if i > 0 && t.Equal(cv.ends[i-1]) {
+			continue
+		}
+		if n := cv.CountAt(t); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// Timeline returns a normalized Timeline covering every span of time that is covered by at least k entries
+func (cv *Coverage) Timeline(k int) Timeline {
+	var (
+		res Timeline
+		cur span
+		ok  bool
+	)
+	for _, cs := range cv.segments {
+		if cs.count < k {
+			continue
+		}
+		if ok && cur.end.Equal(cs.span.start) {
+			cur.end = cs.span.end
+			continue
+		}
+		if ok {
+			res = append(res, cv.cfg.entry(cur))
+		}
+		cur, ok = cs.span, true
+	}
+	if ok {
+		res = append(res, cv.cfg.entry(cur))
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestCoverage(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2000, time.January, 1, h, 0, 0, 0, time.UTC) }
+	shift := func(sh, eh int) timeline.Entry { return timeline.Must(timeline.NewEntry(hour(sh), hour(eh))) }
+	cv := timeline.Config{Boundary: timeline.BoundaryHalfOpen}.NewCoverage(
+		shift(9, 17),
+		shift(9, 12),
+		shift(12, 20),
+		shift(14, 15),
+		shift(21, 22),
+	)
+	// the two shifts from 09:00 to 12:00 and 12:00 to 20:00 are adjacent, so the count stays the same at 12:00
+	expected := []timeline.CoverageSegment{
+		{Entry: shift(9, 14), Count: 2},
+		{Entry: shift(14, 15), Count: 3},
+		{Entry: shift(15, 17), Count: 2},
+		{Entry: shift(17, 20), Count: 1},
+		{Entry: shift(21, 22), Count: 1},
+	}
+	testIsSameCoverage(t, cv.Segments(), expected)
+	testIsSameCoverage(t, cv.AtLeast(2), expected[:3])
+
+	if cv.Max() != 3 {
+		t.Errorf("Expected max of 3, got %d", cv.Max())
+	}
+	counts := map[int]int{8: 0, 9: 2, 12: 2, 14: 3, 15: 2, 17: 1, 20: 0, 21: 1, 22: 0}
+	for h, expected := range counts {
+		if got := cv.CountAt(hour(h)); got != expected {
+			t.Errorf("CountAt(%02d:00): expected %d, got %d", h, expected, got)
+		}
+	}
+
+	tls := map[int]timeline.Timeline{
+		1: {shift(9, 20), shift(21, 22)},
+		2: {shift(9, 17)},
+		3: {shift(14, 15)},
+		4: nil,
+	}
+	for k, expected := range tls {
+		if got := cv.Timeline(k); !testIsSameTimeline(got, expected) {
+			t.Errorf("Timeline(%d): expected:\n\t%s\nGot:\n\t%s", k, printTimeline(expected), printTimeline(got))
+		}
+	}
+}
+
+func TestCoverageBoundary(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2000, time.January, 1, h, 0, 0, 0, time.UTC) }
+	shift := func(sh, eh int) timeline.Entry { return timeline.Must(timeline.NewEntry(hour(sh), hour(eh))) }
+	entries := []timeline.Entry{shift(9, 12), shift(12, 17), shift(12, 14), shift(14, 15)}
+	cases := []struct {
+		name   string
+		cfg    timeline.Config
+		counts map[int]int
+		max    int
+	}{
+		{"default", timeline.Config{}, map[int]int{8: 0, 9: 1, 12: 3, 14: 3, 15: 2, 16: 1, 17: 1, 18: 0}, 3},
+		{"half-open", timeline.Config{Boundary: timeline.BoundaryHalfOpen}, map[int]int{8: 0, 9: 1, 12: 2, 14: 2, 15: 1, 16: 1, 17: 0, 18: 0}, 2},
+		{"closed", timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: time.Hour}, map[int]int{8: 0, 9: 1, 12: 3, 14: 3, 15: 2, 16: 1, 17: 1, 18: 0}, 3},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			cv := tc.cfg.NewCoverage(entries...)
+			for h, expected := range tc.counts {
+				if got := cv.CountAt(hour(h)); got != expected {
+					tt.Errorf("CountAt(%02d:00): expected %d, got %d", h, expected, got)
+				}
+				// CountAt() must agree w/ Contains() for each entry
+				n := 0
+				for _, e := range entries {
+					if ok, _, _ := tc.cfg.Contains(timeline.Timeline{e}, hour(h)); ok {
+						n++
+					}
+				}
+				if n != expected {
+					tt.Errorf("Contains(%02d:00): expected %d entries, got %d", h, expected, n)
+				}
+			}
+			if got := cv.Max(); got != tc.max {
+				tt.Errorf("Expected max of %d, got %d", tc.max, got)
+			}
+		})
+	}
+}
+
+func testIsSameCoverage(t *testing.T, got, expected []timeline.CoverageSegment) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d segments, got %d: %v", len(expected), len(got), got)
+	}
+	for i, seg := range got {
+		if !testIsSameEntry(seg.Entry, expected[i].Entry) || seg.Count != expected[i].Count {
+			t.Errorf("Segment %d: expected %s x%d, got %s x%d", i, expected[i].Entry, expected[i].Count, seg.Entry, seg.Count)
+		}
+	}
+}
@@ -23,6 +23,10 @@ func EndOfTime() time.Time {
 
 // SetEndOfTime assigns a custom sentinel value to represent the latest possible end date for a timeline
 // entry.
+//
+// This only changes the default used by entries that are created afterwards, existing entries retain the
+// sentinel that was in effect when they were created.  Use Config.EndOfTime to set the sentinel for specific
+// timelines rather than for the entire program.
 func SetEndOfTime(t time.Time) {
 	endOfTimeMtx.Lock()
 	endOfTime = t
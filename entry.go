@@ -5,14 +5,32 @@ import (
 	"time"
 )
 
-// Entry defines a type that represents a single entry in a timeline with a start time and, optionally,
-// an end time that is after the start time.
+// Entry defines a type that represents a single entry in a timeline with, optionally, a start time and an end
+// time that is after the start time.  An entry without a start time (i.e. StartTime() returns the zero time)
+// extends indefinitely into the past.
 type Entry interface {
 	StartTime() time.Time
 	EndTime() (time.Time, bool)
 	Duration() time.Duration
 }
 
+// Merger can be implemented by custom Entry types so that the entries that result from combining, extending,
+// truncating or splitting them are constructed by the type itself, which allows it to retain any metadata
+// (e.g. an ID or source) rather than being replaced by an entry returned by NewEntry().
+//
+// When entries are combined, including any that are entirely covered by the others, Merge is called on the
+// earliest of them that implements Merger, with the others as arguments, and when an entry is truncated or split,
+// Merge is called on it for each resulting part.
+type Merger interface {
+	Entry
+	// Merge returns a new entry with the specified start and end dates, derived from this entry and the others
+	// (if any) that it is being combined with.  As for NewEntry(), a zero start or end indicates that the new
+	// entry has no start or end date.  The entries are being combined according to c, which should be used to
+	// create the new entry (e.g. with c.NewEntry()) since the dates may not be valid for the zero Config, such as
+	// a single instant for BoundaryClosed.
+	Merge(c Config, start, end time.Time, others ...Entry) Entry
+}
+
 // Must panics if err is non-nil, otherwise it returns e
 func Must(e Entry, err error) Entry {
 	if err != nil {
@@ -23,70 +41,110 @@ func Must(e Entry, err error) Entry {
 
 // NewEntry creates a new timeline entry with the specified start and end dates.
 //
-// If end is the zero time, this entry will have no end date
+// If start is the zero time, this entry will have no start date and if end is the zero time, this entry will
+// have no end date
 func NewEntry(start time.Time, end time.Time) (Entry, error) {
-	if start.IsZero() {
-		return nil, ErrInvalidTimelineStart
-	}
-	if !(end.IsZero() || start.Before(end)) {
+	return Config{}.NewEntry(start, end)
+}
+
+// FromStartDate creates a new timeline entry starting on the specified date (at midnight UTC) and no end date
+func FromStartDate(y int, m time.Month, d int) (Entry, error) {
+	return Config{}.FromStartDate(y, m, d)
+}
+
+// ForDateRange creates a new timeline entry starting and ending at midnight UTC on the specified dates
+func ForDateRange(sy int, sm time.Month, sd int, ey int, em time.Month, ed int) (Entry, error) {
+	return Config{}.ForDateRange(sy, sm, sd, ey, em, ed)
+}
+
+// NewEntry creates a new timeline entry with the specified start and end dates, which records the Config so that
+// the methods of a Timeline containing it apply the same rules.
+//
+// If start is the zero time, this entry will have no start date.  If end is the zero time, this entry will have
+// no end date, any other value (including the EndOfTime sentinel) is treated as a genuine end date.  The end
+// date must be after the start date, except for BoundaryClosed where they may be equal.
+func (c Config) NewEntry(start time.Time, end time.Time) (Entry, error) {
+	if !(start.IsZero() || end.IsZero() || start.Before(end) || (c.Boundary == BoundaryClosed && start.Equal(end))) {
 		return nil, ErrInvalidTimelineOrder
 	}
-	if end.IsZero() {
-		end = EndOfTime()
-	}
 	e := entry{
 		start: start,
 		end:   end,
+		cfg:   c,
+	}
+	if end.IsZero() {
+		e.end, e.open = c.endOfTime(), true
 	}
 	return e, nil
 }
 
-// FromStartDate creates a new timeline entry starting on the specified date (at midnight UTC) and no end date
-func FromStartDate(y int, m time.Month, d int) (Entry, error) {
-	return NewEntry(
-		time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+// FromStartDate creates a new timeline entry starting on the specified date (at midnight in the configured
+// Location) and no end date
+func (c Config) FromStartDate(y int, m time.Month, d int) (Entry, error) {
+	return c.NewEntry(
+		time.Date(y, m, d, 0, 0, 0, 0, c.location()),
 		time.Time{},
 	)
 }
 
-// ForDateRange creates a new timeline entry starting and ending at midnight UTC on the specified dates
-func ForDateRange(sy int, sm time.Month, sd int, ey int, em time.Month, ed int) (Entry, error) {
-	return NewEntry(
-		time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC),
-		time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC),
+// ForDateRange creates a new timeline entry starting and ending at midnight in the configured Location on the
+// specified dates
+func (c Config) ForDateRange(sy int, sm time.Month, sd int, ey int, em time.Month, ed int) (Entry, error) {
+	return c.NewEntry(
+		time.Date(sy, sm, sd, 0, 0, 0, 0, c.location()),
+		time.Date(ey, em, ed, 0, 0, 0, 0, c.location()),
 	)
 }
 
 type entry struct {
 	start time.Time
 	end   time.Time
+	open  bool   // true if the entry has no end date, in which case end is the EndOfTime sentinel at creation
+	cfg   Config // the Config that created the entry, which is used by the methods of Timeline
 }
 
-// StartTime implements Entry.StartTime() and returns the start time for this timeline entry
+// StartTime implements Entry.StartTime() and returns the start time for this timeline entry, or the zero time
+// if the entry does not have a start
 func (e entry) StartTime() time.Time {
 	return e.start
 }
 
 // EndTime implements Entry.EndTime() and returns the end time for this timeline entry, along with a
 // boolean value indicating if an end time was present
+//
+// If the entry does not have an end, the EndOfTime sentinel that was in effect when it was created is returned.
 func (e entry) EndTime() (time.Time, bool) {
-	return e.end, !e.end.Equal(EndOfTime())
+	return e.end, !e.open
 }
 
 // Duration returns the period between the start and end time for this timeline entry.  If the entry
-// does not have an end, the period between the start and EndOfTime (midnight UTC on 9999-12-31) is returned
+// does not have an end, the period between the start and the EndOfTime sentinel is returned, and if the entry
+// does not have a start, the maximum time.Duration is returned
 func (e entry) Duration() time.Duration {
+	if e.start.IsZero() {
+		return time.Duration(1<<63 - 1)
+	}
 	return e.end.Sub(e.start)
 }
 
 // String implements fmt.Stringer for entry instances
 //
 // The returned string contains the span of the entry in range notation with the following format: [<start> .. <end>].
-// If this entry does not have an end date, the result is formatted as "[<start> .. -)" (to indicate no upper bound).
+// If this entry does not have an end date, the result is formatted as "[<start> .. -)" (to indicate no upper bound)
+// and if it does not have a start date, the result is formatted as "(- .. <end>]" (to indicate no lower bound).
 // The date values are printed according to the time.RFC3339 format.
 func (e entry) String() string {
+	return e.format(time.RFC3339)
+}
+
+// format returns the span of the entry in range notation, using the specified layout for the date values
+func (e entry) format(layout string) string {
+	start := "(-"
+	if !e.start.IsZero() {
+		start = "[" + e.start.Format(layout)
+	}
 	if end, hasEnd := e.EndTime(); hasEnd {
-		return fmt.Sprintf("[%s .. %s]", e.start.Format(time.RFC3339), end.Format(time.RFC3339))
+		return fmt.Sprintf("%s .. %s]", start, end.Format(layout))
 	}
-	return fmt.Sprintf("[%s .. -)", e.start.Format(time.RFC3339))
+	return fmt.Sprintf("%s .. -)", start)
 }
@@ -1,12 +1,34 @@
 package timeline
 
 const (
-	// ErrInvalidTimelineStart is returned by NewEntry() if the start time is the zero time
+	// ErrInvalidTimelineStart was returned by NewEntry() if the start time is the zero time.
+	//
+	// Deprecated: entries without a start time are now supported and extend indefinitely into the past, so this
+	// error is no longer returned.
 	ErrInvalidTimelineStart = timelineError("The start time must be specified for a timeline entry")
 	// ErrInvalidTimelineOrder is returned by NewEntry() if the start time is equal to or later than the end time
 	ErrInvalidTimelineOrder = timelineError("The start time must be before the end time for a timeline entry")
+	// ErrNilEntry indicates that a timeline entry is nil
+	ErrNilEntry = timelineError("A timeline entry must not be nil")
+	// ErrUnsortedTimeline indicates that the entries of a timeline are not sorted by start time
+	ErrUnsortedTimeline = timelineError("The timeline entries must be sorted by start time")
+	// ErrOverlappingEntries indicates that the entries of a timeline overlap one another
+	ErrOverlappingEntries = timelineError("The timeline entries must not overlap")
+	// ErrAdjacentEntries indicates that the entries of a timeline are adjacent to one another, rather than being
+	// combined into a single entry
+	ErrAdjacentEntries = timelineError("Adjacent timeline entries must be combined")
+	// ErrInvalidRecordedTime is returned by Bitemporal.Record() and Bitemporal.Retract() if the recorded time is the
+	// zero time or earlier than a previously recorded change
+	ErrInvalidRecordedTime = timelineError("The recorded time must not be earlier than a previously recorded change")
 	// ErrInvalidIntersectionType indicates that a string could not be parsed into an IntersectionType enum value
 	ErrInvalidIntersectionType = timelineError("The provided string could not be parsed into an IntersectionType value")
+	// ErrInvalidAllenRelation indicates that a string could not be parsed into an AllenRelation enum value
+	ErrInvalidAllenRelation = timelineError("The provided string could not be parsed into an AllenRelation value")
+	// ErrUnsupportedRangeBound is returned by PGRange.Scan() and PGMultirange.Scan() if an inclusive or exclusive
+	// range bound cannot be represented using the configured Boundary
+	ErrUnsupportedRangeBound = timelineError("The range bound cannot be represented using the configured Boundary")
+	// ErrInvalidBoundary indicates that a string could not be parsed into a Boundary enum value
+	ErrInvalidBoundary = timelineError("The provided string could not be parsed into a Boundary value")
 )
 
 // timelineError defines a custom type so that we can define error constants
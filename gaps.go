@@ -0,0 +1,69 @@
+package timeline
+
+import "time"
+
+// GapOption defines a function that customizes the behavior of Timeline.Gaps() and Timeline.Complement()
+type GapOption func(*gapOptions)
+
+type gapOptions struct {
+	minDuration time.Duration
+}
+
+// MinGapDuration returns a GapOption that excludes any gap that is shorter than d.  Gaps without an end
+// are never excluded.
+func MinGapDuration(d time.Duration) GapOption {
+	return func(o *gapOptions) {
+		o.minDuration = d
+	}
+}
+
+// Gaps returns a new normalized timeline containing the spans of time within window that are not covered by
+// any of the timeline entries.
+//
+// If window does not have an end, the final gap (if any) will not have an end either.  The timeline is
+// expected to be normalized.
+func (tl Timeline) Gaps(window Entry, opts ...GapOption) Timeline {
+	return configOf(tl, Timeline{window}).Gaps(tl, window, opts...)
+}
+
+// Complement returns a new normalized timeline containing all spans of time from BeginningOfTime() onwards
+// that are not covered by any of the timeline entries.
+//
+// The timeline is expected to be normalized.
+func (tl Timeline) Complement(opts ...GapOption) Timeline {
+	return configOf(tl).Complement(tl, opts...)
+}
+
+// Gaps returns a new normalized timeline containing the spans of time within window that are not covered by
+// any of the timeline entries.
+//
+// If window does not have an end, the final gap (if any) will not have an end either.  The timeline is
+// expected to be normalized.
+func (c Config) Gaps(tl Timeline, window Entry, opts ...GapOption) Timeline {
+	var o gapOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+	gaps := c.Difference(Timeline{window}, tl)
+	if o.minDuration <= 0 {
+		return gaps
+	}
+	res := gaps[:0]
+	for _, g := range gaps {
+		if _, hasEnd := g.EndTime(); hasEnd {
+			if s := c.spanOf(g); s.end.Sub(s.start) < o.minDuration {
+				continue
+			}
+		}
+		res = append(res, g)
+	}
+	return res
+}
+
+// Complement returns a new normalized timeline containing all spans of time from the configured
+// BeginningOfTime sentinel onwards that are not covered by any of the timeline entries.
+//
+// The timeline is expected to be normalized.
+func (c Config) Complement(tl Timeline, opts ...GapOption) Timeline {
+	return c.Gaps(tl, Must(c.NewEntry(c.beginningOfTime(), time.Time{})), opts...)
+}
@@ -0,0 +1,85 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestGaps(t *testing.T) {
+	tl := timeline.New(
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2001, time.January, 2, 2004, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2005, time.January, 1, 2006, time.January, 1)),
+		timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+	)
+	cases := []struct {
+		name     string
+		window   timeline.Entry
+		opts     []timeline.GapOption
+		expected timeline.Timeline
+	}{
+		{
+			"window covers all entries",
+			timeline.Must(timeline.ForDateRange(1999, time.January, 1, 2011, time.January, 1)),
+			nil,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(1999, time.January, 1, 2000, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2001, time.January, 2)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2006, time.January, 1, 2010, time.January, 1)),
+			),
+		},
+		{
+			"window within entries",
+			timeline.Must(timeline.ForDateRange(2003, time.January, 1, 2005, time.June, 1)),
+			nil,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+			),
+		},
+		{
+			"minimum duration",
+			timeline.Must(timeline.ForDateRange(1999, time.January, 1, 2011, time.January, 1)),
+			[]timeline.GapOption{timeline.MinGapDuration(48 * time.Hour)},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(1999, time.January, 1, 2000, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2006, time.January, 1, 2010, time.January, 1)),
+			),
+		},
+		{
+			"open-ended window",
+			timeline.Must(timeline.FromStartDate(2005, time.June, 1)),
+			nil,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2006, time.January, 1, 2010, time.January, 1)),
+			),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			got := tl.Gaps(tc.window, tc.opts...)
+			if !testIsSameTimeline(got, tc.expected) {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected), printTimeline(got))
+			}
+		})
+	}
+}
+
+func TestComplement(t *testing.T) {
+	tl := timeline.New(
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+	)
+	expected := timeline.New(
+		timeline.Must(timeline.NewEntry(timeline.BeginningOfTime(), time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))),
+		timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2004, time.January, 1)),
+		timeline.Must(timeline.FromStartDate(2005, time.January, 1)),
+	)
+	got := tl.Complement()
+	if !testIsSameTimeline(got, expected) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(got))
+	}
+}
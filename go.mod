@@ -1,5 +1,5 @@
 module github.com/code-willing/go-timeline
 
-go 1.12
+go 1.18
 
 require github.com/pkg/errors v0.8.1
@@ -0,0 +1,259 @@
+package timeline
+
+import "time"
+
+// Index stores an arbitrary collection of timeline entries, which may overlap or duplicate one another (unlike
+// the entries of a normalized Timeline), and efficiently answers queries for the entries that overlap a given
+// range or contain a given time.
+//
+// The index is implemented as an augmented interval tree (a balanced binary search tree ordered by start time,
+// where each node also tracks the latest end time within its subtree), so insertion and deletion take O(log n)
+// time and queries take O(log n + k) time, where k is the number of matching entries.
+//
+// The zero value is an empty Index that uses the zero Config.  An Index is not safe for concurrent use.
+type Index struct {
+	cfg  Config
+	root *indexNode
+	size int
+}
+
+type indexNode struct {
+	entry  Entry
+	span   span
+	maxEnd time.Time // latest end of any span in the subtree rooted at this node
+	height int
+	left   *indexNode
+	right  *indexNode
+}
+
+// NewIndex returns a new Index containing the specified entries
+func NewIndex(entries ...Entry) *Index {
+	return configOf(entries).NewIndex(entries...)
+}
+
+// NewIndex returns a new Index containing the specified entries, which are compared according to the Config
+func (c Config) NewIndex(entries ...Entry) *Index {
+	idx := &Index{cfg: c}
+	for _, e := range entries {
+		idx.Insert(e)
+	}
+	return idx
+}
+
+// Len returns the number of entries in the index
+func (idx *Index) Len() int {
+	return idx.size
+}
+
+// Insert adds an entry to the index.  Entries that are equal to, or overlap, existing entries are retained
+// separately.
+func (idx *Index) Insert(e Entry) {
+	idx.root = idx.root.insert(&indexNode{entry: e, span: idx.cfg.spanOf(e)})
+	idx.size++
+}
+
+// Delete removes an entry from the index and returns a boolean value indicating whether or not it was found.
+//
+// Entries are compared using ==, so the dynamic type of e must be comparable (as are the entries returned by
+// NewEntry()).  If the index contains duplicates of e, only one of them is removed.
+func (idx *Index) Delete(e Entry) bool {
+	var found bool
+	idx.root, found = idx.root.delete(e, idx.cfg.spanOf(e))
+	if found {
+		idx.size--
+	}
+	return found
+}
+
+// Overlapping returns the entries that overlap the span of time covered by e, sorted by start time.
+//
+// Overlapping entries are those that Intersect() e with any IntersectionType other than IntersectionTypeNone
+// and IntersectionTypeAdjacent.
+func (idx *Index) Overlapping(e Entry) []Entry {
+	var res []Entry
+	idx.root.overlapping(idx.cfg.spanOf(e), &res)
+	return res
+}
+
+// Containing returns the entries that contain the specified time, sorted by start time.
+//
+// Whether or not an entry contains its end time is determined in the same way as Contains().
+func (idx *Index) Containing(t time.Time) []Entry {
+	var res []Entry
+	idx.root.containing(t, idx.cfg.Boundary != BoundaryDefault, &res)
+	return res
+}
+
+// Entries returns all of the entries in the index, sorted by start time
+func (idx *Index) Entries() []Entry {
+	res := make([]Entry, 0, idx.size)
+	idx.root.walk(func(n *indexNode) {
+		res = append(res, n.entry)
+	})
+	return res
+}
+
+func (n *indexNode) insert(nn *indexNode) *indexNode {
+	if n == nil {
+		nn.height, nn.maxEnd = 1, nn.span.end
+		return nn
+	}
+	if spanLess(nn.span, n.span) {
+		n.left = n.left.insert(nn)
+	} else {
+		n.right = n.right.insert(nn)
+	}
+	return n.rebalance()
+}
+
+// delete removes the node for e, which has the span s, from the subtree rooted at n and returns the new root of
+// the subtree along with a boolean value indicating whether or not it was found
+func (n *indexNode) delete(e Entry, s span) (*indexNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	var found bool
+	switch {
+	case spanLess(s, n.span):
+		n.left, found = n.left.delete(e, s)
+	case spanLess(n.span, s):
+		n.right, found = n.right.delete(e, s)
+	case n.entry == e:
+		return n.remove(), true
+	default:
+		// entries w/ the same span may be on either side after rebalancing
+		if n.left, found = n.left.delete(e, s); !found {
+			n.right, found = n.right.delete(e, s)
+		}
+	}
+	if !found {
+		return n, false
+	}
+	return n.rebalance(), true
+}
+
+// remove removes n from the tree and returns the node that replaces it
+func (n *indexNode) remove() *indexNode {
+	if n.left == nil {
+		return n.right
+	}
+	if n.right == nil {
+		return n.left
+	}
+	// replace n w/ the leftmost node of the right subtree
+	var m *indexNode
+	n.right, m = n.right.removeMin()
+	m.left, m.right = n.left, n.right
+	return m.rebalance()
+}
+
+// removeMin removes the leftmost node from the subtree rooted at n and returns the new root of the subtree
+// along with the removed node
+func (n *indexNode) removeMin() (*indexNode, *indexNode) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var m *indexNode
+	n.left, m = n.left.removeMin()
+	return n.rebalance(), m
+}
+
+func (n *indexNode) overlapping(s span, res *[]Entry) {
+	// no span in this subtree ends after s starts
+	if n == nil || !n.maxEnd.After(s.start) {
+		return
+	}
+	n.left.overlapping(s, res)
+	// this node and every node in the right subtree start at or after s ends
+	if !n.span.start.Before(s.end) {
+		return
+	}
+	if n.span.end.After(s.start) {
+		*res = append(*res, n.entry)
+	}
+	n.right.overlapping(s, res)
+}
+
+func (n *indexNode) containing(t time.Time, exclusive bool, res *[]Entry) {
+	if n == nil || n.maxEnd.Before(t) || (exclusive && n.maxEnd.Equal(t)) {
+		return
+	}
+	n.left.containing(t, exclusive, res)
+	if n.span.start.After(t) {
+		return
+	}
+	if n.span.end.After(t) || (!exclusive && n.span.end.Equal(t)) {
+		*res = append(*res, n.entry)
+	}
+	n.right.containing(t, exclusive, res)
+}
+
+func (n *indexNode) walk(fn func(*indexNode)) {
+	if n == nil {
+		return
+	}
+	n.left.walk(fn)
+	fn(n)
+	n.right.walk(fn)
+}
+
+func (n *indexNode) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recalculates the height and maximum end of n from its children
+func (n *indexNode) update() {
+	n.height = 1 + maxInt(n.left.getHeight(), n.right.getHeight())
+	n.maxEnd = n.span.end
+	if n.left != nil {
+		n.maxEnd = laterOf(n.maxEnd, n.left.maxEnd)
+	}
+	if n.right != nil {
+		n.maxEnd = laterOf(n.maxEnd, n.right.maxEnd)
+	}
+}
+
+// rebalance restores the AVL invariant for the subtree rooted at n, whose children are expected to be balanced,
+// and returns the new root of the subtree
+func (n *indexNode) rebalance() *indexNode {
+	n.update()
+	switch bf := n.left.getHeight() - n.right.getHeight(); {
+	case bf > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case bf < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *indexNode) rotateLeft() *indexNode {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *indexNode) rotateRight() *indexNode {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
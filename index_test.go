@@ -0,0 +1,104 @@
+package timeline_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestIndex(t *testing.T) {
+	var (
+		rnd     = rand.New(rand.NewSource(1))
+		base    = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+		entries []timeline.Entry
+	)
+	randomEntry := func() timeline.Entry {
+		st := base.Add(time.Duration(rnd.Intn(1000)) * time.Hour)
+		switch rnd.Intn(10) {
+		case 0:
+			return timeline.Must(timeline.NewEntry(st, time.Time{}))
+		case 1:
+			return timeline.Must(timeline.NewEntry(time.Time{}, st))
+		default:
+			return timeline.Must(timeline.NewEntry(st, st.Add(time.Duration(1+rnd.Intn(48))*time.Hour)))
+		}
+	}
+	for _, cfg := range []timeline.Config{{}, {Boundary: timeline.BoundaryHalfOpen}} {
+		t.Run(cfg.Boundary.String(), func(tt *testing.T) {
+			entries = entries[:0]
+			idx := cfg.NewIndex()
+			for i := 0; i < 500; i++ {
+				e := randomEntry()
+				entries = append(entries, e)
+				idx.Insert(e)
+			}
+			// insert and delete a duplicate
+			idx.Insert(entries[0])
+			if !idx.Delete(entries[0]) {
+				tt.Fatalf("Expected duplicate entry to be deleted")
+			}
+			testIndex(tt, cfg, rnd, idx, entries)
+
+			// delete half of the entries
+			rnd.Shuffle(len(entries), func(i, j int) { entries[i], entries[j] = entries[j], entries[i] })
+			for _, e := range entries[250:] {
+				if !idx.Delete(e) {
+					tt.Fatalf("Expected %s to be deleted", e)
+				}
+			}
+			entries = entries[:250]
+			if idx.Delete(timeline.Must(timeline.FromStartDate(1900, time.January, 1))) {
+				tt.Errorf("Expected Delete() to return false for a missing entry")
+			}
+			testIndex(tt, cfg, rnd, idx, entries)
+		})
+	}
+}
+
+func testIndex(t *testing.T, cfg timeline.Config, rnd *rand.Rand, idx *timeline.Index, entries []timeline.Entry) {
+	t.Helper()
+	if idx.Len() != len(entries) || len(idx.Entries()) != len(entries) {
+		t.Fatalf("Expected %d entries, got %d", len(entries), idx.Len())
+	}
+	base := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		st := base.Add(time.Duration(rnd.Intn(1100)-50) * time.Hour)
+		q := timeline.Must(timeline.NewEntry(st, st.Add(time.Duration(1+rnd.Intn(24))*time.Hour)))
+		var expected int
+		for _, e := range entries {
+			if it := cfg.Intersect(e, q); it != timeline.IntersectionTypeNone && it != timeline.IntersectionTypeAdjacent {
+				expected++
+			}
+		}
+		got := idx.Overlapping(q)
+		if len(got) != expected {
+			t.Errorf("Overlapping(%s): expected %d entries, got %d", q, expected, len(got))
+		}
+		for j := 1; j < len(got); j++ {
+			if got[j].StartTime().Before(got[j-1].StartTime()) {
+				t.Errorf("Overlapping(%s): entries are not sorted", q)
+			}
+		}
+
+		expected = 0
+		for _, e := range entries {
+			if ok, _, _ := cfg.Contains(timeline.Timeline{e}, st); ok {
+				expected++
+			}
+		}
+		if got := idx.Containing(st); len(got) != expected {
+			t.Errorf("Containing(%s): expected %d entries, got %d", st, expected, len(got))
+		}
+	}
+}
+
+func BenchmarkIndexOverlapping(b *testing.B) {
+	idx := timeline.NewIndex(benchmarkTimeline(100000)...)
+	q := timeline.Must(timeline.ForDateRange(2100, time.January, 1, 2100, time.January, 8))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Overlapping(q)
+	}
+}
@@ -0,0 +1,98 @@
+package timeline
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// jsonEntry is the JSON representation of a timeline entry, where entries without a start or end date have a
+// null start or end
+type jsonEntry struct {
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end"`
+}
+
+func toJSONEntry(e Entry) jsonEntry {
+	var je jsonEntry
+	if start := e.StartTime(); !start.IsZero() {
+		je.Start = &start
+	}
+	if end, hasEnd := e.EndTime(); hasEnd {
+		je.End = &end
+	}
+	return je
+}
+
+func (je jsonEntry) entry(c Config) (Entry, error) {
+	var start, end time.Time
+	if je.Start != nil {
+		start = *je.Start
+	}
+	if je.End != nil {
+		end = *je.End
+	}
+	return c.NewEntry(start, end)
+}
+
+// MarshalEntryJSON returns the JSON encoding of any Entry implementation, in the same format as the entries
+// returned by NewEntry(): {"start": "<start>", "end": "<end>"}, where "start" is null if e has no start date and
+// "end" is null if e has no end date.
+func MarshalEntryJSON(e Entry) ([]byte, error) {
+	return json.Marshal(toJSONEntry(e))
+}
+
+// UnmarshalEntryJSON parses the JSON encoding of a timeline entry, as generated by MarshalEntryJSON(), and
+// returns the resulting Entry
+//
+// Since Entry is an interface, json.Unmarshal() cannot decode directly into an Entry value, so this is the only
+// way to decode a single entry.  Timeline values can be decoded with json.Unmarshal().
+func UnmarshalEntryJSON(p []byte) (Entry, error) {
+	var je jsonEntry
+	if err := json.Unmarshal(p, &je); err != nil {
+		return nil, err
+	}
+	return je.entry(Config{})
+}
+
+// MarshalJSON implements json.Marshaler for entry instances
+func (e entry) MarshalJSON() ([]byte, error) {
+	return MarshalEntryJSON(e)
+}
+
+// MarshalJSON implements json.Marshaler for Timeline values
+//
+// The timeline is encoded as a JSON array of entries, in the format generated by MarshalEntryJSON().
+func (tl Timeline) MarshalJSON() ([]byte, error) {
+	v := make([]jsonEntry, len(tl))
+	for i, e := range tl {
+		v[i] = toJSONEntry(e)
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Timeline values
+//
+// The decoded entries do not need to be sorted or non-overlapping, since the resulting timeline is normalized.
+// The entries are created by the Config of the existing timeline, if any, as for the methods of Timeline.
+func (tl *Timeline) UnmarshalJSON(p []byte) error {
+	if string(p) == "null" {
+		return nil
+	}
+	var v []jsonEntry
+	if err := json.Unmarshal(p, &v); err != nil {
+		return err
+	}
+	c := configOf(*tl)
+	entries := make([]Entry, len(v))
+	for i, je := range v {
+		e, err := je.entry(c)
+		if err != nil {
+			return errors.Wrapf(err, "invalid timeline entry at index %d", i)
+		}
+		entries[i] = e
+	}
+	*tl = c.New(entries...)
+	return nil
+}
@@ -0,0 +1,97 @@
+package timeline_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestEntryJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    timeline.Entry
+		expected string
+	}{
+		{
+			"with end",
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			`{"start":"2000-01-01T00:00:00Z","end":"2001-01-01T00:00:00Z"}`,
+		},
+		{
+			"without end",
+			timeline.Must(timeline.FromStartDate(2000, time.January, 1)),
+			`{"start":"2000-01-01T00:00:00Z","end":null}`,
+		},
+		{
+			"without start",
+			timeline.Must(timeline.NewEntry(time.Time{}, time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC))),
+			`{"start":null,"end":"2001-01-01T00:00:00Z"}`,
+		},
+		{
+			"ending at end of time",
+			timeline.Must(timeline.NewEntry(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), timeline.EndOfTime())),
+			`{"start":"2000-01-01T00:00:00Z","end":"9999-12-31T23:59:59.999999999Z"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			p, err := json.Marshal(tc.value)
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if string(p) != tc.expected {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", tc.expected, p)
+			}
+			e, err := timeline.UnmarshalEntryJSON(p)
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if !testIsSameEntry(e, tc.value) {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", tc.value, e)
+			}
+			_, hasEnd := e.EndTime()
+			if _, expected := tc.value.EndTime(); hasEnd != expected {
+				tt.Errorf("Expected hasEnd to be %v, got %v", expected, hasEnd)
+			}
+		})
+	}
+}
+
+func TestTimelineJSON(t *testing.T) {
+	var tl timeline.Timeline
+	err := json.Unmarshal([]byte(`[
+		{"start": "2004-01-01T00:00:00Z", "end": "2005-01-01T00:00:00Z"},
+		{"start": "2010-01-01T00:00:00Z"},
+		{"start": "2000-01-01T00:00:00Z", "end": "2001-01-01T00:00:00Z"},
+		{"start": "2000-06-01T00:00:00Z", "end": "2002-01-01T00:00:00Z"}
+	]`), &tl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := timeline.New(
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2002, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+		timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+	)
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+
+	p, err := json.Marshal(tl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedJSON := `[{"start":"2000-01-01T00:00:00Z","end":"2002-01-01T00:00:00Z"},` +
+		`{"start":"2004-01-01T00:00:00Z","end":"2005-01-01T00:00:00Z"},` +
+		`{"start":"2010-01-01T00:00:00Z","end":null}]`
+	if string(p) != expectedJSON {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", expectedJSON, p)
+	}
+
+	err = json.Unmarshal([]byte(`[{"start": "2004-01-01T00:00:00Z", "end": "2003-01-01T00:00:00Z"}]`), &tl)
+	if err == nil {
+		t.Errorf("Expected an error for an entry that ends before it starts")
+	}
+}
@@ -0,0 +1,63 @@
+package timeline
+
+import "sort"
+
+// LayeredSegment represents a span of time within a flattened set of layered timelines, along with the index
+// of the layer that it was taken from
+type LayeredSegment struct {
+	Entry
+	Layer int
+}
+
+// LayeredValue represents the value of a segment within a flattened set of layered value timelines, along
+// with the index of the layer that it was taken from
+type LayeredValue[T any] struct {
+	Layer int
+	Value T
+}
+
+// Layer flattens an ordered list of timelines, where each layer masks those that follow it (i.e. layers[0] has
+// the highest priority), and returns the resulting segments sorted by start time.
+//
+// Each span of time covered by any of the layers is covered by exactly one segment, which is tagged with the
+// index of the highest priority layer that covers it.  The timelines are expected to be normalized.
+func Layer(layers ...Timeline) []LayeredSegment {
+	return configOf(layers...).Layer(layers...)
+}
+
+// Layer flattens an ordered list of timelines, as for the package level Layer(), according to the Config
+func (c Config) Layer(layers ...Timeline) []LayeredSegment {
+	var (
+		res     []LayeredSegment
+		covered Timeline
+	)
+	for i, tl := range layers {
+		for _, e := range c.Difference(tl, covered) {
+			res = append(res, LayeredSegment{Entry: e, Layer: i})
+		}
+		covered = c.Union(covered, tl)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return spanLess(c.spanOf(res[i].Entry), c.spanOf(res[j].Entry))
+	})
+	return res
+}
+
+// LayerValues flattens an ordered list of value timelines, where each layer masks those that follow it (i.e.
+// layers[0] has the highest priority), and returns the resulting value timeline.
+//
+// The value of each segment is tagged with the index of the highest priority layer that has a value for it.
+// Nil layers are treated as empty.
+func LayerValues[T any](layers ...*ValueTimeline[T]) *ValueTimeline[LayeredValue[T]] {
+	res := NewValueTimeline[LayeredValue[T]](Override[LayeredValue[T]], nil)
+	// add the layers in increasing order of priority, so that each one overrides those that were added before it
+	for i := len(layers) - 1; i >= 0; i-- {
+		if layers[i] == nil {
+			continue
+		}
+		for _, seg := range layers[i].segments {
+			res.Add(seg.Entry, LayeredValue[T]{Layer: i, Value: seg.Value})
+		}
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestLayer(t *testing.T) {
+	month := func(sm, em time.Month) timeline.Entry {
+		return timeline.Must(timeline.ForDateRange(2000, sm, 1, 2000, em, 1))
+	}
+	var (
+		override = timeline.New(month(time.March, time.April))
+		defaults = timeline.New(month(time.February, time.May), month(time.June, time.July))
+		fallback = timeline.New(timeline.Must(timeline.FromStartDate(2000, time.January, 1)))
+	)
+	expected := []timeline.LayeredSegment{
+		{Entry: month(time.January, time.February), Layer: 2},
+		{Entry: month(time.February, time.March), Layer: 1},
+		{Entry: month(time.March, time.April), Layer: 0},
+		{Entry: month(time.April, time.May), Layer: 1},
+		{Entry: month(time.May, time.June), Layer: 2},
+		{Entry: month(time.June, time.July), Layer: 1},
+		{Entry: timeline.Must(timeline.FromStartDate(2000, time.July, 1)), Layer: 2},
+	}
+	got := timeline.Layer(override, defaults, fallback)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d segments, got %d: %v", len(expected), len(got), got)
+	}
+	for i, seg := range got {
+		if !testIsSameEntry(seg.Entry, expected[i].Entry) || seg.Layer != expected[i].Layer {
+			t.Errorf("Segment %d: expected %s from layer %d, got %s from layer %d", i, expected[i].Entry,
+				expected[i].Layer, seg.Entry, seg.Layer)
+		}
+	}
+}
+
+func TestLayerValues(t *testing.T) {
+	month := func(sm, em time.Month) timeline.Entry {
+		return timeline.Must(timeline.ForDateRange(2000, sm, 1, 2000, em, 1))
+	}
+	override := timeline.NewValueTimeline[string](nil, nil)
+	override.Add(month(time.March, time.April), "holiday")
+	defaults := timeline.NewValueTimeline[string](nil, nil)
+	defaults.Add(month(time.January, time.June), "weekly")
+
+	lv := timeline.LayerValues(override, nil, defaults)
+	cases := []struct {
+		month    time.Month
+		expected timeline.LayeredValue[string]
+		ok       bool
+	}{
+		{time.January, timeline.LayeredValue[string]{Layer: 2, Value: "weekly"}, true},
+		{time.March, timeline.LayeredValue[string]{Layer: 0, Value: "holiday"}, true},
+		{time.April, timeline.LayeredValue[string]{Layer: 2, Value: "weekly"}, true},
+		{time.July, timeline.LayeredValue[string]{}, false},
+	}
+	for _, tc := range cases {
+		v, ok := lv.ValueAt(time.Date(2000, tc.month, 15, 0, 0, 0, 0, time.UTC))
+		if ok != tc.ok || v != tc.expected {
+			t.Errorf("%s: expected %v (%v), got %v (%v)", tc.month, tc.expected, tc.ok, v, ok)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package timeline_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+// sourceEntry is a custom Entry implementation that records the source(s) of each entry
+type sourceEntry struct {
+	timeline.Entry
+	Source string
+}
+
+func (e sourceEntry) Merge(c timeline.Config, start, end time.Time, others ...timeline.Entry) timeline.Entry {
+	sources := []string{e.Source}
+	for _, o := range others {
+		if se, ok := o.(sourceEntry); ok {
+			sources = append(sources, se.Source)
+		}
+	}
+	return sourceEntry{Entry: timeline.Must(c.NewEntry(start, end)), Source: strings.Join(sources, "+")}
+}
+
+func newSourceEntry(source string, sy, ey int) timeline.Entry {
+	return sourceEntry{
+		Entry:  timeline.Must(timeline.ForDateRange(sy, time.January, 1, ey, time.January, 1)),
+		Source: source,
+	}
+}
+
+func TestMerger(t *testing.T) {
+	days := timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}
+	cases := []struct {
+		name     string
+		fn       func() (timeline.Timeline, error)
+		expected []string
+	}{
+		{
+			"add/extend",
+			func() (timeline.Timeline, error) {
+				tl := timeline.New(newSourceEntry("a", 2000, 2002))
+				_, err := tl.Add(newSourceEntry("b", 2001, 2003))
+				return tl, err
+			},
+			[]string{"a+b"},
+		},
+		{
+			"add/cover",
+			func() (timeline.Timeline, error) {
+				tl := timeline.New(newSourceEntry("a", 2001, 2002), newSourceEntry("b", 2003, 2004))
+				_, err := tl.Add(newSourceEntry("c", 2000, 2005))
+				return tl, err
+			},
+			[]string{"c+a+b"},
+		},
+		{
+			"add multiple/cover",
+			func() (timeline.Timeline, error) {
+				tl := timeline.New(newSourceEntry("a", 2001, 2002), newSourceEntry("b", 2003, 2004))
+				_, err := tl.Add(newSourceEntry("c", 2000, 2005), newSourceEntry("d", 2010, 2011))
+				return tl, err
+			},
+			[]string{"c+a+b", "d"},
+		},
+		{
+			"add/same start",
+			func() (timeline.Timeline, error) {
+				tl := timeline.New(newSourceEntry("a", 2000, 2005))
+				_, err := tl.Add(newSourceEntry("b", 2000, 2008))
+				return tl, err
+			},
+			[]string{"b+a"},
+		},
+		{
+			"add multiple/same start",
+			func() (timeline.Timeline, error) {
+				tl := timeline.New(newSourceEntry("a", 2000, 2005))
+				_, err := tl.Add(newSourceEntry("b", 2000, 2008), newSourceEntry("c", 2020, 2021))
+				return tl, err
+			},
+			[]string{"b+a", "c"},
+		},
+		{
+			"persistent/same start",
+			func() (timeline.Timeline, error) {
+				pt, _, err := timeline.NewPersistent(newSourceEntry("a", 2000, 2005)).Add(newSourceEntry("b", 2000, 2008))
+				return pt.Timeline(), err
+			},
+			[]string{"b+a"},
+		},
+		{
+			"normalize",
+			func() (timeline.Timeline, error) {
+				return timeline.New(newSourceEntry("b", 2001, 2003), newSourceEntry("a", 2000, 2002), newSourceEntry("c", 2005, 2006)), nil
+			},
+			[]string{"a+b", "c"},
+		},
+		{
+			"normalize/cover",
+			func() (timeline.Timeline, error) {
+				return timeline.New(newSourceEntry("a", 2001, 2002), newSourceEntry("c", 2000, 2005)), nil
+			},
+			[]string{"c+a"},
+		},
+		{
+			"union/cover",
+			func() (timeline.Timeline, error) {
+				return timeline.New(newSourceEntry("c", 2000, 2005)).Union(timeline.New(newSourceEntry("a", 2001, 2002))), nil
+			},
+			[]string{"c+a"},
+		},
+		{
+			"remove/split",
+			func() (timeline.Timeline, error) {
+				tl := timeline.New(newSourceEntry("a", 2000, 2005))
+				_, err := tl.Remove(timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)))
+				return tl, err
+			},
+			[]string{"a", "a"},
+		},
+		{
+			"remove/closed single instant",
+			func() (timeline.Timeline, error) {
+				e := sourceEntry{Entry: timeline.Must(days.ForDateRange(2000, time.January, 1, 2000, time.January, 3)), Source: "a"}
+				tl := days.New(e)
+				_, err := days.Remove(&tl, timeline.Must(days.ForDateRange(2000, time.January, 2, 2000, time.January, 2)))
+				return tl, err
+			},
+			[]string{"a", "a"},
+		},
+		{
+			"intersection",
+			func() (timeline.Timeline, error) {
+				return timeline.New(newSourceEntry("a", 2000, 2005)).Intersection(timeline.New(newSourceEntry("b", 2002, 2003))), nil
+			},
+			[]string{"a+b"},
+		},
+		{
+			"plain entry merged w/ custom",
+			func() (timeline.Timeline, error) {
+				tl := timeline.New(timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2002, time.January, 1)))
+				_, err := tl.Add(newSourceEntry("b", 2001, 2003))
+				return tl, err
+			},
+			[]string{"b"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tl, err := tc.fn()
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if len(tl) != len(tc.expected) {
+				tt.Fatalf("Expected %d entries, got %s", len(tc.expected), printTimeline(tl))
+			}
+			for i, e := range tl {
+				se, ok := e.(sourceEntry)
+				if !ok || se.Source != tc.expected[i] {
+					tt.Errorf("Expected entry %d to have source %q, got %#v", i, tc.expected[i], e)
+				}
+			}
+		})
+	}
+}
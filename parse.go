@@ -0,0 +1,198 @@
+package timeline
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ParseError describes a problem parsing a timeline entry or timeline from range notation
+type ParseError struct {
+	// Input is the string that was being parsed
+	Input string
+	// Pos is the byte offset within Input at which the problem was found
+	Pos int
+	// Msg describes the problem
+	Msg string
+	// Err is the underlying error, if any (e.g. a *time.ParseError or ErrInvalidTimelineOrder)
+	Err error
+}
+
+// Error implements error for ParseError values
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("Unable to parse %q at position %d: %s", e.Input, e.Pos, e.Msg)
+}
+
+// Unwrap returns the underlying error, if any
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseEntry parses a timeline entry in the range notation generated by the String() method of the entries
+// returned by NewEntry(), i.e. "[<start> .. <end>]", "[<start> .. -)" for an entry without an end date or
+// "(- .. <end>]" for an entry without a start date.
+//
+// The dates must be in time.RFC3339 format, optionally with fractional seconds.  Any errors are returned as a
+// *ParseError.  Since Entry is an interface, this is the only way to decode the text marshalled by the entries.
+func ParseEntry(s string) (Entry, error) {
+	p := rangeParser{s: s}
+	p.skipSpace()
+	e, err := p.parseEntry()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected trailing characters")
+	}
+	return e, nil
+}
+
+// ParseTimeline parses a list of timeline entries in range notation, enclosed in square brackets and separated
+// by whitespace and/or commas (e.g. "[[<start> .. <end>], [<start> .. -)]"), and returns the resulting
+// normalized Timeline.
+//
+// This is the format generated by formatting a Timeline with fmt's %v verb.  A single entry without the
+// enclosing brackets is also accepted.  Any errors are returned as a *ParseError.
+func ParseTimeline(s string) (Timeline, error) {
+	p := rangeParser{s: s}
+	p.skipSpace()
+	listPos := p.pos
+	// a single entry w/out the enclosing list starts w/ '(' if it has no start date, or w/ '[' followed by a date
+	single := p.peek("(")
+	if !single {
+		if !p.consume("[") {
+			return nil, p.errorf("expected '['")
+		}
+		p.skipSpace()
+		single = !p.peek("[") && !p.peek("(") && !p.peek("]")
+	}
+	var entries []Entry
+	if single {
+		p.pos = listPos
+		e, err := p.parseEntry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	} else {
+		for p.skipSpace(); !p.consume("]"); p.skipSpace() {
+			if len(entries) > 0 && p.consume(",") {
+				p.skipSpace()
+			}
+			e, err := p.parseEntry()
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		}
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected trailing characters")
+	}
+	return New(entries...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler for entry instances.
+//
+// The marshalled value is the range notation returned by .String(), except that fractional seconds are
+// retained so that the value can be parsed back into an identical entry by ParseEntry().
+func (e entry) MarshalText() ([]byte, error) {
+	return []byte(e.format(time.RFC3339Nano)), nil
+}
+
+// rangeParser is a simple recursive descent parser for the range notation of timeline entries
+type rangeParser struct {
+	s   string
+	pos int
+}
+
+func (p *rangeParser) parseEntry() (Entry, error) {
+	var (
+		entryPos = p.pos
+		start    time.Time
+		err      error
+	)
+	switch {
+	case p.consume("("):
+		p.skipSpace()
+		if !p.consume("-") {
+			return nil, p.errorf("expected '-'")
+		}
+	case p.consume("["):
+		p.skipSpace()
+		if start, err = p.parseTime(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf("expected '[' or '('")
+	}
+	p.skipSpace()
+	if !p.consume("..") {
+		return nil, p.errorf("expected '..'")
+	}
+	p.skipSpace()
+	var end time.Time
+	if p.consume("-") {
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+	} else {
+		if end, err = p.parseTime(); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume("]") {
+			return nil, p.errorf("expected ']'")
+		}
+	}
+	e, err := NewEntry(start, end)
+	if err != nil {
+		return nil, &ParseError{Input: p.s, Pos: entryPos, Msg: err.Error(), Err: err}
+	}
+	return e, nil
+}
+
+func (p *rangeParser) parseTime() (time.Time, error) {
+	start := p.pos
+	for !p.eof() && !p.peek("..") && !strings.ContainsRune("[](),", rune(p.s[p.pos])) && !unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return time.Time{}, p.errorf("expected a date")
+	}
+	t, err := time.Parse(time.RFC3339Nano, p.s[start:p.pos])
+	if err != nil {
+		return time.Time{}, &ParseError{Input: p.s, Pos: start, Msg: "invalid date", Err: err}
+	}
+	return t, nil
+}
+
+func (p *rangeParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *rangeParser) peek(tok string) bool {
+	return strings.HasPrefix(p.s[p.pos:], tok)
+}
+
+func (p *rangeParser) consume(tok string) bool {
+	if p.peek(tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *rangeParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *rangeParser) errorf(format string, args ...interface{}) *ParseError {
+	return &ParseError{Input: p.s, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
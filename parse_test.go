@@ -0,0 +1,153 @@
+package timeline_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestParseEntry(t *testing.T) {
+	cases := []struct {
+		name        string
+		value       string
+		expected    timeline.Entry
+		expectedPos int
+	}{
+		{
+			"with end",
+			"[2000-01-01T00:00:00Z .. 2001-01-01T00:00:00Z]",
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			-1,
+		},
+		{
+			"without end",
+			"  [2000-01-01T00:00:00Z .. -)",
+			timeline.Must(timeline.FromStartDate(2000, time.January, 1)),
+			-1,
+		},
+		{
+			"compact/fractional seconds/offset",
+			"[2000-01-01T00:00:00.5-05:00..2001-01-01T00:00:00Z]",
+			timeline.Must(timeline.NewEntry(
+				time.Date(2000, time.January, 1, 5, 0, 0, 500000000, time.UTC),
+				time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC),
+			)),
+			-1,
+		},
+		{
+			"without start",
+			"(- .. 2001-01-01T00:00:00Z]",
+			timeline.Must(timeline.NewEntry(time.Time{}, time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC))),
+			-1,
+		},
+		{"without start or end", "(-..-)", timeline.Must(timeline.NewEntry(time.Time{}, time.Time{})), -1},
+		{"missing open bracket", "2000-01-01T00:00:00Z .. -)", nil, 0},
+		{"start date w/ open bracket", "(2000-01-01T00:00:00Z .. -)", nil, 1},
+		{"invalid start", "[2000-13-01T00:00:00Z .. -)", nil, 1},
+		{"missing separator", "[2000-01-01T00:00:00Z 2001-01-01T00:00:00Z]", nil, 22},
+		{"invalid end bracket", "[2000-01-01T00:00:00Z .. 2001-01-01T00:00:00Z)", nil, 45},
+		{"invalid open end bracket", "[2000-01-01T00:00:00Z .. -]", nil, 26},
+		{"end before start", "[2001-01-01T00:00:00Z .. 2000-01-01T00:00:00Z]", nil, 0},
+		{"trailing characters", "[2000-01-01T00:00:00Z .. -) x", nil, 28},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			got, err := timeline.ParseEntry(tc.value)
+			if tc.expected != nil {
+				if err != nil {
+					tt.Fatalf("Unexpected error: %v", err)
+				}
+				if !testIsSameEntry(got, tc.expected) {
+					tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", tc.expected, got)
+				}
+				return
+			}
+			var perr *timeline.ParseError
+			if !errors.As(err, &perr) {
+				tt.Fatalf("Expected a *ParseError, got %v", err)
+			}
+			if perr.Pos != tc.expectedPos {
+				tt.Errorf("Expected error at position %d, got %d (%v)", tc.expectedPos, perr.Pos, err)
+			}
+		})
+	}
+}
+
+func TestParseEntryErrors(t *testing.T) {
+	_, err := timeline.ParseEntry("[2001-01-01T00:00:00Z .. 2000-01-01T00:00:00Z]")
+	if !errors.Is(err, timeline.ErrInvalidTimelineOrder) {
+		t.Errorf("Expected ErrInvalidTimelineOrder, got %v", err)
+	}
+}
+
+func TestParseTimeline(t *testing.T) {
+	expected := timeline.New(
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2002, time.January, 1)),
+		timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+	)
+	cases := []struct {
+		name     string
+		value    string
+		expected timeline.Timeline
+	}{
+		{"formatted timeline", fmt.Sprint(expected), expected},
+		{
+			"comma separated/denormalized",
+			"[[2010-01-01T00:00:00Z .. -), [2000-01-01T00:00:00Z .. 2001-01-01T00:00:00Z],[2001-01-01T00:00:00Z .. 2002-01-01T00:00:00Z]]",
+			expected,
+		},
+		{"empty", " [ ] ", timeline.New()},
+		{"single entry", "[2010-01-01T00:00:00Z .. -)", expected[1:]},
+		{
+			"without start",
+			"[[2010-01-01T00:00:00Z .. -), [2000-01-01T00:00:00Z .. 2002-01-01T00:00:00Z] (- .. 2000-06-01T00:00:00Z]]",
+			timeline.Timeline{
+				timeline.Must(timeline.NewEntry(time.Time{}, time.Date(2002, time.January, 1, 0, 0, 0, 0, time.UTC))),
+				expected[1],
+			},
+		},
+		{
+			"single entry without start",
+			"(- .. 2000-06-01T00:00:00Z]",
+			timeline.Timeline{timeline.Must(timeline.NewEntry(time.Time{}, time.Date(2000, time.June, 1, 0, 0, 0, 0, time.UTC)))},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			got, err := timeline.ParseTimeline(tc.value)
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if !testIsSameTimeline(got, tc.expected) {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected), printTimeline(got))
+			}
+		})
+	}
+
+	var perr *timeline.ParseError
+	_, err := timeline.ParseTimeline("[[2000-01-01T00:00:00Z .. -) x]")
+	if !errors.As(err, &perr) || perr.Pos != 29 {
+		t.Errorf("Expected a *ParseError at position 29, got %v", err)
+	}
+}
+
+func TestEntryText(t *testing.T) {
+	e := timeline.Must(timeline.NewEntry(
+		time.Date(2000, time.January, 1, 0, 0, 0, 123, time.UTC),
+		time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC),
+	))
+	p, err := e.(interface{ MarshalText() ([]byte, error) }).MarshalText()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := timeline.ParseEntry(string(p))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !testIsSameEntry(got, e) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", e, got)
+	}
+}
@@ -0,0 +1,275 @@
+package timeline
+
+import "time"
+
+// PersistentTimeline is an immutable timeline, where each modification returns a new version that shares
+// most of its structure with the previous one rather than copying it, so that every historical version can be
+// retained cheaply.
+//
+// The entries are stored in a balanced binary search tree ordered by start time, and modifications copy only
+// the O(log n) nodes on the paths to the entries that changed.  Entries are merged in the same way as for
+// Timeline.Add(), so the entries of a PersistentTimeline are always normalized.
+//
+// The zero value is an empty PersistentTimeline that uses the zero Config.  PersistentTimeline values are safe
+// for concurrent use, since they are never modified.
+type PersistentTimeline struct {
+	cfg  Config
+	root *persistentNode
+	size int
+}
+
+// persistentNode is a node of the tree underlying a PersistentTimeline, which is never modified once created
+type persistentNode struct {
+	entry  Entry
+	span   span
+	height int
+	left   *persistentNode
+	right  *persistentNode
+}
+
+// NewPersistent returns a new PersistentTimeline containing the specified entries
+func NewPersistent(entries ...Entry) PersistentTimeline {
+	return configOf(entries).NewPersistent(entries...)
+}
+
+// NewPersistent returns a new PersistentTimeline containing the specified entries, which uses the Config for
+// all operations
+func (c Config) NewPersistent(entries ...Entry) PersistentTimeline {
+	pt := PersistentTimeline{cfg: c}
+	for _, e := range c.New(entries...) {
+		pt.root = pt.root.insert(e, c.spanOf(e))
+		pt.size++
+	}
+	return pt
+}
+
+// Len returns the number of entries in the timeline
+func (pt PersistentTimeline) Len() int {
+	return pt.size
+}
+
+// Timeline returns the entries of the timeline as a (normalized) Timeline
+func (pt PersistentTimeline) Timeline() Timeline {
+	tl := make(Timeline, 0, pt.size)
+	pt.root.walk(func(n *persistentNode) {
+		tl = append(tl, n.entry)
+	})
+	return tl
+}
+
+// Add returns a new version of the timeline with one or more entries added to it, along with a boolean value
+// indicating whether or not it differs from the existing version.  The existing version is not modified.
+//
+// If any of the entries are invalid, a *ValidationError is returned along with the existing version.
+func (pt PersistentTimeline) Add(entries ...Entry) (PersistentTimeline, bool, error) {
+	if err := pt.cfg.validateEntries(entries); err != nil {
+		return pt, false, err
+	}
+	changed := false
+	for _, e := range entries {
+		var ok bool
+		if pt, ok = pt.addEntry(e); ok {
+			changed = true
+		}
+	}
+	return pt, changed, nil
+}
+
+func (pt PersistentTimeline) addEntry(e Entry) (PersistentTimeline, bool) {
+	ns := pt.cfg.spanOf(e)
+	ms, merged := ns, pt.matching(ns, func(rs span) bool {
+		return pt.cfg.mergeType(rs, ns) != IntersectionTypeNone
+	})
+	for _, n := range merged {
+		switch intersectSpans(n.span, ns) {
+		case IntersectionTypeSame, IntersectionTypeWithin:
+			// specified date range is already covered, no-op
+			return pt, false
+		}
+		ms.start, ms.end = earlierOf(ms.start, n.span.start), laterOf(ms.end, n.span.end)
+	}
+	sources := make([]Entry, 0, len(merged)+1)
+	for _, n := range merged {
+		pt.root = pt.root.delete(n.span.start)
+		pt.size--
+		if len(sources) == 0 && !n.span.start.Before(ns.start) {
+			// keep the sources in chronological order, w/ the new entry first if they start at the same time (as
+			// for Timeline.Add())
+			sources = append(sources, e)
+		}
+		sources = append(sources, n.entry)
+	}
+	if len(merged) > 0 {
+		if len(sources) == len(merged) {
+			sources = append(sources, e)
+		}
+		e = pt.cfg.derive(ms, sources...)
+	}
+	pt.root = pt.root.insert(e, ms)
+	pt.size++
+	return pt, true
+}
+
+// Remove returns a new version of the timeline with the spans of time covered by one or more entries removed
+// from it, along with a boolean value indicating whether or not it differs from the existing version.  The
+// existing version is not modified.
+//
+// If any of the entries are invalid, a *ValidationError is returned along with the existing version.
+func (pt PersistentTimeline) Remove(entries ...Entry) (PersistentTimeline, bool, error) {
+	if err := pt.cfg.validateEntries(entries); err != nil {
+		return pt, false, err
+	}
+	changed := false
+	for _, e := range entries {
+		ns := pt.cfg.spanOf(e)
+		for _, n := range pt.matching(ns, func(rs span) bool {
+			it := intersectSpans(rs, ns)
+			return it != IntersectionTypeNone && it != IntersectionTypeAdjacent
+		}) {
+			// replace the existing entry w/ the portions before and after the removed range, if any
+			pt.root = pt.root.delete(n.span.start)
+			pt.size--
+			for _, s := range []span{{start: n.span.start, end: ns.start}, {start: ns.end, end: n.span.end}} {
+				if s.start.Before(s.end) {
+					pt.root = pt.root.insert(pt.cfg.derive(s, n.entry), s)
+					pt.size++
+				}
+			}
+			changed = true
+		}
+	}
+	return pt, changed, nil
+}
+
+// Contains determines whether or not the specified time falls within one of the timeline entries and, if it
+// does, returns the start and end of the entry
+func (pt PersistentTimeline) Contains(t time.Time) (bool, time.Time, time.Time) {
+	if t.IsZero() {
+		return false, time.Time{}, time.Time{}
+	}
+	// find the first entry whose span ends at or after t, as for Config.Contains()
+	exclusive := pt.cfg.Boundary != BoundaryDefault
+	var found *persistentNode
+	for n := pt.root; n != nil; {
+		if n.span.end.After(t) || (!exclusive && n.span.end.Equal(t)) {
+			found, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	if found == nil || found.span.start.After(t) {
+		return false, time.Time{}, time.Time{}
+	}
+	ed, _ := found.entry.EndTime()
+	return true, found.entry.StartTime(), ed
+}
+
+// matching returns the nodes for which fn returns true, in order, where fn is expected to only match nodes
+// whose spans overlap or are adjacent to s
+func (pt PersistentTimeline) matching(s span, fn func(span) bool) []*persistentNode {
+	var res []*persistentNode
+	var visit func(n *persistentNode)
+	visit = func(n *persistentNode) {
+		if n == nil {
+			return
+		}
+		// since the spans do not overlap, their ends are in the same order as their starts
+		if !n.span.end.Before(s.start) {
+			visit(n.left)
+		} else {
+			visit(n.right)
+			return
+		}
+		if n.span.start.After(s.end) {
+			return
+		}
+		if fn(n.span) {
+			res = append(res, n)
+		}
+		visit(n.right)
+	}
+	visit(pt.root)
+	return res
+}
+
+func (n *persistentNode) walk(fn func(*persistentNode)) {
+	if n == nil {
+		return
+	}
+	n.left.walk(fn)
+	fn(n)
+	n.right.walk(fn)
+}
+
+// insert returns the root of a new tree containing the nodes of the tree rooted at n plus a new node for e
+func (n *persistentNode) insert(e Entry, s span) *persistentNode {
+	if n == nil {
+		return &persistentNode{entry: e, span: s, height: 1}
+	}
+	if s.start.Before(n.span.start) {
+		return n.with(n.left.insert(e, s), n.right)
+	}
+	return n.with(n.left, n.right.insert(e, s))
+}
+
+// delete returns the root of a new tree containing the nodes of the tree rooted at n, minus the node whose
+// span starts at start
+func (n *persistentNode) delete(start time.Time) *persistentNode {
+	switch {
+	case n == nil:
+		return nil
+	case start.Before(n.span.start):
+		return n.with(n.left.delete(start), n.right)
+	case start.After(n.span.start):
+		return n.with(n.left, n.right.delete(start))
+	case n.left == nil:
+		return n.right
+	case n.right == nil:
+		return n.left
+	}
+	// replace n w/ the leftmost node of the right subtree
+	m := n.right
+	for m.left != nil {
+		m = m.left
+	}
+	return m.with(n.left, n.right.delete(m.span.start))
+}
+
+// with returns a copy of n with the specified children, rebalanced if necessary
+func (n *persistentNode) with(left, right *persistentNode) *persistentNode {
+	switch bf := left.getHeight() - right.getHeight(); {
+	case bf > 1:
+		if left.left.getHeight() < left.right.getHeight() {
+			// rotate left child to the left, then rotate right
+			lr := left.right
+			return lr.node(left.node(left.left, lr.left), n.node(lr.right, right))
+		}
+		return left.node(left.left, n.node(left.right, right))
+	case bf < -1:
+		if right.right.getHeight() < right.left.getHeight() {
+			// rotate right child to the right, then rotate left
+			rl := right.left
+			return rl.node(n.node(left, rl.left), right.node(rl.right, right.right))
+		}
+		return right.node(n.node(left, right.left), right.right)
+	}
+	return n.node(left, right)
+}
+
+// node returns a copy of n with the specified children, without rebalancing
+func (n *persistentNode) node(left, right *persistentNode) *persistentNode {
+	return &persistentNode{
+		entry:  n.entry,
+		span:   n.span,
+		height: 1 + maxInt(left.getHeight(), right.getHeight()),
+		left:   left,
+		right:  right,
+	}
+}
+
+func (n *persistentNode) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
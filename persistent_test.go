@@ -0,0 +1,84 @@
+package timeline_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestPersistentTimeline(t *testing.T) {
+	var (
+		rnd      = rand.New(rand.NewSource(1))
+		base     = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+		tl       timeline.Timeline
+		pt       timeline.PersistentTimeline
+		versions []timeline.PersistentTimeline
+		expected []timeline.Timeline
+	)
+	for i := 0; i < 500; i++ {
+		st := base.Add(time.Duration(rnd.Intn(2000)) * time.Hour)
+		e := timeline.Must(timeline.NewEntry(st, st.Add(time.Duration(1+rnd.Intn(24))*time.Hour)))
+		if rnd.Intn(50) == 0 {
+			e = timeline.Must(timeline.NewEntry(st, time.Time{}))
+		}
+		var changed, pchanged bool
+		if rnd.Intn(4) == 0 {
+			changed, _ = tl.Remove(e)
+			pt, pchanged, _ = pt.Remove(e)
+		} else {
+			changed, _ = tl.Add(e)
+			pt, pchanged, _ = pt.Add(e)
+		}
+		if changed != pchanged {
+			t.Fatalf("Step %d (%s): expected changed to be %v, got %v", i, e, changed, pchanged)
+		}
+		if got := pt.Timeline(); !testIsSameTimeline(got, tl) || pt.Len() != len(tl) {
+			t.Fatalf("Step %d (%s): expected:\n\t%s\nGot:\n\t%s", i, e, printTimeline(tl), printTimeline(got))
+		}
+		versions = append(versions, pt)
+		expected = append(expected, tl.Clone())
+	}
+	// earlier versions are unaffected by subsequent changes
+	for i, v := range versions {
+		if got := v.Timeline(); !testIsSameTimeline(got, expected[i]) {
+			t.Fatalf("Version %d: expected:\n\t%s\nGot:\n\t%s", i, printTimeline(expected[i]), printTimeline(got))
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		v := base.Add(time.Duration(rnd.Intn(2100)) * time.Hour)
+		ok1, st1, et1 := tl.Contains(v)
+		ok2, st2, et2 := pt.Contains(v)
+		if ok1 != ok2 || !st1.Equal(st2) || !et1.Equal(et2) {
+			t.Errorf("Contains(%s): expected %v [%s .. %s], got %v [%s .. %s]", v, ok1, st1, et1, ok2, st2, et2)
+		}
+	}
+}
+
+func TestPersistentTimelineNew(t *testing.T) {
+	entries := []timeline.Entry{
+		timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2001, time.June, 1)),
+	}
+	expected := timeline.New(entries...)
+	if got := timeline.NewPersistent(entries...).Timeline(); !testIsSameTimeline(got, expected) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(got))
+	}
+}
+
+func TestPersistentTimelineInvalid(t *testing.T) {
+	y2000 := timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1))
+	pt := timeline.NewPersistent(y2000)
+	if got, changed, err := pt.Add(nil); !errors.Is(err, timeline.ErrNilEntry) || changed || got.Len() != 1 {
+		t.Errorf("Expected ErrNilEntry w/ the timeline unmodified, got %v and %s", err, printTimeline(got.Timeline()))
+	}
+	if got, changed, err := pt.Add(reversedEntry{}); !errors.Is(err, timeline.ErrInvalidTimelineOrder) || changed || got.Len() != 1 {
+		t.Errorf("Expected ErrInvalidTimelineOrder w/ the timeline unmodified, got %v and %s", err, printTimeline(got.Timeline()))
+	}
+	if got, changed, err := pt.Remove(y2000, nil); !errors.Is(err, timeline.ErrNilEntry) || changed || got.Len() != 1 {
+		t.Errorf("Expected ErrNilEntry w/ the timeline unmodified, got %v and %s", err, printTimeline(got.Timeline()))
+	}
+}
@@ -0,0 +1,288 @@
+package timeline
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	pgEmptyRange       = "empty"
+	pgInfinity         = "infinity"
+	pgNegativeInfinity = "-infinity"
+	pgTimestampLayout  = "2006-01-02 15:04:05.999999999-07:00"
+)
+
+// pgTimestampLayouts contains the layouts used to parse timestamps in PostgreSQL's textual output format, which
+// omits the minutes of the UTC offset if they are zero
+var pgTimestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	time.RFC3339Nano,
+}
+
+// PGRange adapts a timeline entry for use with PostgreSQL tstzrange columns via database/sql, by implementing
+// sql.Scanner and driver.Valuer using PostgreSQL's textual range format (e.g. ["2000-01-01 00:00:00+00",)).
+//
+// An unbounded (or infinity) upper bound maps to an entry without an end date and an unbounded (or -infinity)
+// lower bound maps to an entry without a start date, while infinity as the lower bound or -infinity as the upper
+// bound is rejected.  Both empty ranges and NULL values are represented by a nil Entry.
+//
+// The inclusive/exclusive bound flags are mapped according to the Boundary of the configured Config:
+//   - BoundaryDefault and BoundaryHalfOpen entries are written as "[start,end)", the form that PostgreSQL uses
+//     by default, and ranges with an exclusive lower bound or an inclusive upper bound are rejected
+//   - BoundaryClosed entries are written as "[start,end]", and exclusive bounds are moved inwards by the
+//     configured Granularity (as PostgreSQL does for discrete range types)
+//
+// The bound flags of an unbounded lower or upper bound are ignored.
+type PGRange struct {
+	Entry  Entry
+	Config Config
+}
+
+// Scan implements sql.Scanner for PGRange values
+func (r *PGRange) Scan(src interface{}) error {
+	s, err := pgText(src)
+	if err != nil || s == "" {
+		r.Entry = nil
+		return err
+	}
+	p := pgRangeParser{s: s, cfg: r.Config}
+	e, err := p.parseRange()
+	if err == nil && !p.eof() {
+		err = p.newError("unexpected trailing characters")
+	}
+	if err != nil {
+		return err
+	}
+	r.Entry = e
+	return nil
+}
+
+// Value implements driver.Valuer for PGRange values
+func (r PGRange) Value() (driver.Value, error) {
+	if r.Entry == nil {
+		return pgEmptyRange, nil
+	}
+	return r.Config.pgFormatRange(r.Entry), nil
+}
+
+// PGMultirange adapts a timeline for use with PostgreSQL tstzmultirange columns via database/sql, by
+// implementing sql.Scanner and driver.Valuer using PostgreSQL's textual multirange format
+// (e.g. {["2000-01-01 00:00:00+00","2001-01-01 00:00:00+00"),["2002-01-01 00:00:00+00",)}).
+//
+// The individual ranges are mapped to timeline entries according to the configured Config in the same way as
+// PGRange, and the scanned timeline is normalized.  A NULL value is scanned as an empty timeline.
+type PGMultirange struct {
+	Timeline Timeline
+	Config   Config
+}
+
+// Scan implements sql.Scanner for PGMultirange values
+func (m *PGMultirange) Scan(src interface{}) error {
+	s, err := pgText(src)
+	if err != nil || s == "" {
+		m.Timeline = nil
+		return err
+	}
+	p := pgRangeParser{s: s, cfg: m.Config}
+	if !p.consume('{') {
+		return p.newError("expected '{'")
+	}
+	var entries []Entry
+	for !p.consume('}') {
+		if len(entries) > 0 && !p.consume(',') {
+			return p.newError("expected ',' or '}'")
+		}
+		e, err := p.parseRange()
+		if err != nil {
+			return err
+		}
+		if e != nil {
+			entries = append(entries, e)
+		}
+	}
+	if !p.eof() {
+		return p.newError("unexpected trailing characters")
+	}
+	m.Timeline = m.Config.New(entries...)
+	return nil
+}
+
+// Value implements driver.Valuer for PGMultirange values
+func (m PGMultirange) Value() (driver.Value, error) {
+	parts := make([]string, len(m.Timeline))
+	for i, e := range m.Timeline {
+		parts[i] = m.Config.pgFormatRange(e)
+	}
+	return "{" + strings.Join(parts, ",") + "}", nil
+}
+
+// pgText returns the text representation of a value returned by a database driver
+func pgText(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(v), nil
+	case []byte:
+		return strings.TrimSpace(string(v)), nil
+	default:
+		return "", errors.Errorf("Unable to scan a value of type %T into a PostgreSQL range", src)
+	}
+}
+
+// pgFormatRange returns the PostgreSQL textual range representation of e, according to the configured Boundary
+func (c Config) pgFormatRange(e Entry) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	if st := e.StartTime(); !st.IsZero() {
+		sb.WriteString(`"` + st.Format(pgTimestampLayout) + `"`)
+	}
+	sb.WriteString(",")
+	et, hasEnd := e.EndTime()
+	switch {
+	case !hasEnd:
+		sb.WriteString(")")
+	case c.Boundary == BoundaryClosed:
+		sb.WriteString(`"` + et.Format(pgTimestampLayout) + `"]`)
+	default:
+		sb.WriteString(`"` + et.Format(pgTimestampLayout) + `")`)
+	}
+	return sb.String()
+}
+
+// pgRangeParser parses PostgreSQL's textual range format
+type pgRangeParser struct {
+	s   string
+	pos int
+	cfg Config
+}
+
+// parseRange parses a single range, returning a nil Entry for an empty range
+func (p *pgRangeParser) parseRange() (Entry, error) {
+	if strings.HasPrefix(strings.ToLower(p.s[p.pos:]), pgEmptyRange) {
+		p.pos += len(pgEmptyRange)
+		return nil, nil
+	}
+	rangePos := p.pos
+	lowerInc := p.consume('[')
+	if !lowerInc && !p.consume('(') {
+		return nil, p.newError("expected '[' or '('")
+	}
+	lower, err := p.parseBound(true)
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(',') {
+		return nil, p.newError("expected ','")
+	}
+	upper, err := p.parseBound(false)
+	if err != nil {
+		return nil, err
+	}
+	upperInc := p.consume(']')
+	if !upperInc && !p.consume(')') {
+		return nil, p.newError("expected ']' or ')'")
+	}
+	e, err := p.cfg.pgEntry(lower, lowerInc, upper, upperInc)
+	if err != nil {
+		return nil, &ParseError{Input: p.s, Pos: rangePos, Msg: err.Error(), Err: err}
+	}
+	return e, nil
+}
+
+// parseBound parses a single (possibly quoted) range bound, returning the zero time for an unbounded value or
+// an infinite value in the direction of the bound
+func (p *pgRangeParser) parseBound(lower bool) (time.Time, error) {
+	var (
+		sb     strings.Builder
+		start  = p.pos
+		quoted bool
+	)
+	for !p.eof() {
+		c := p.s[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.s):
+			sb.WriteByte(p.s[p.pos+1])
+			p.pos += 2
+			continue
+		case c == '"' && quoted && p.pos+1 < len(p.s) && p.s[p.pos+1] == '"':
+			sb.WriteByte('"')
+			p.pos += 2
+			continue
+		case c == '"':
+			quoted = !quoted
+		case !quoted && strings.IndexByte(",])", c) >= 0:
+			return p.parseTimestamp(start, sb.String(), lower)
+		default:
+			sb.WriteByte(c)
+		}
+		p.pos++
+	}
+	return time.Time{}, p.newError("unterminated range")
+}
+
+func (p *pgRangeParser) parseTimestamp(pos int, s string, lower bool) (time.Time, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return time.Time{}, nil
+	case pgNegativeInfinity:
+		if lower {
+			return time.Time{}, nil
+		}
+		return time.Time{}, &ParseError{Input: p.s, Pos: pos, Msg: "-infinity is not a valid upper bound"}
+	case pgInfinity:
+		if !lower {
+			return time.Time{}, nil
+		}
+		return time.Time{}, &ParseError{Input: p.s, Pos: pos, Msg: "infinity is not a valid lower bound"}
+	}
+	var err error
+	for _, layout := range pgTimestampLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, &ParseError{Input: p.s, Pos: pos, Msg: "invalid timestamp", Err: err}
+}
+
+// pgEntry creates a new timeline entry from the bounds of a range and their inclusive flags, where a zero
+// bound is unbounded
+func (c Config) pgEntry(lower time.Time, lowerInc bool, upper time.Time, upperInc bool) (Entry, error) {
+	if !lower.IsZero() && !lowerInc {
+		if c.Boundary != BoundaryClosed {
+			return nil, ErrUnsupportedRangeBound
+		}
+		lower = lower.Add(c.granularity())
+	}
+	if !upper.IsZero() {
+		switch {
+		case c.Boundary != BoundaryClosed && upperInc:
+			return nil, ErrUnsupportedRangeBound
+		case c.Boundary == BoundaryClosed && !upperInc:
+			upper = upper.Add(-c.granularity())
+		}
+	}
+	return c.NewEntry(lower, upper)
+}
+
+func (p *pgRangeParser) consume(c byte) bool {
+	if !p.eof() && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *pgRangeParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *pgRangeParser) newError(msg string) *ParseError {
+	return &ParseError{Input: p.s, Pos: p.pos, Msg: msg}
+}
@@ -0,0 +1,225 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestPGRangeScan(t *testing.T) {
+	halfOpen := timeline.Config{Boundary: timeline.BoundaryHalfOpen}
+	days := timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}
+	const (
+		a = `"2000-01-01 00:00:00+00"`
+		b = `"2001-01-01 00:00:00+00"`
+	)
+	cases := []struct {
+		name     string
+		cfg      timeline.Config
+		value    interface{}
+		expected timeline.Entry
+		isError  bool
+	}{
+		{
+			"default/inclusive lower",
+			timeline.Config{},
+			"[" + a + "," + b + ")",
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			false,
+		},
+		{"default/inclusive", timeline.Config{}, "[" + a + "," + b + "]", nil, true},
+		{"default/exclusive lower", timeline.Config{}, "(" + a + "," + b + "]", nil, true},
+		{"default/exclusive", timeline.Config{}, "(" + a + "," + b + ")", nil, true},
+		{
+			"half-open/inclusive lower",
+			halfOpen,
+			"[" + a + "," + b + ")",
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			false,
+		},
+		{"half-open/inclusive", halfOpen, "[" + a + "," + b + "]", nil, true},
+		{"half-open/exclusive lower", halfOpen, "(" + a + "," + b + "]", nil, true},
+		{"half-open/exclusive", halfOpen, "(" + a + "," + b + ")", nil, true},
+		{
+			"closed/inclusive",
+			days,
+			"[" + a + "," + b + "]",
+			timeline.Must(days.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			false,
+		},
+		{
+			"closed/exclusive upper",
+			days,
+			"[" + a + "," + b + ")",
+			timeline.Must(days.ForDateRange(2000, time.January, 1, 2000, time.December, 31)),
+			false,
+		},
+		{
+			"closed/exclusive lower",
+			days,
+			"(" + a + "," + b + "]",
+			timeline.Must(days.ForDateRange(2000, time.January, 2, 2001, time.January, 1)),
+			false,
+		},
+		{
+			"closed/exclusive",
+			days,
+			"(" + a + "," + b + ")",
+			timeline.Must(days.ForDateRange(2000, time.January, 2, 2000, time.December, 31)),
+			false,
+		},
+		{
+			"unbounded upper/offset",
+			halfOpen,
+			[]byte(`["2000-01-01 05:30:00.5+05:30",)`),
+			timeline.Must(timeline.NewEntry(time.Date(2000, time.January, 1, 0, 0, 0, 500000000, time.UTC), time.Time{})),
+			false,
+		},
+		{
+			"infinity upper",
+			timeline.Config{},
+			"[" + a + ",infinity]",
+			timeline.Must(timeline.FromStartDate(2000, time.January, 1)),
+			false,
+		},
+		{
+			"unbounded lower",
+			halfOpen,
+			"(," + b + ")",
+			timeline.Must(timeline.NewEntry(time.Time{}, time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC))),
+			false,
+		},
+		{
+			"-infinity lower",
+			timeline.Config{},
+			"[-infinity," + b + ")",
+			timeline.Must(timeline.NewEntry(time.Time{}, time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC))),
+			false,
+		},
+		{"infinity lower", halfOpen, "[infinity,)", nil, true},
+		{"-infinity upper", halfOpen, "[" + a + ",-infinity)", nil, true},
+		{"empty", timeline.Config{}, "empty", nil, false},
+		{"null", timeline.Config{}, nil, nil, false},
+		{"invalid timestamp", halfOpen, `["2000-13-01 00:00:00+00",)`, nil, true},
+		{"missing bound", timeline.Config{}, "[" + a + "]", nil, true},
+		{"unsupported type", timeline.Config{}, 42, nil, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			r := timeline.PGRange{Config: tc.cfg}
+			err := r.Scan(tc.value)
+			if tc.isError {
+				if err == nil {
+					tt.Errorf("Expected an error, got %v", r.Entry)
+				}
+				return
+			}
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if (r.Entry == nil) != (tc.expected == nil) || (r.Entry != nil && !testIsSameEntry(r.Entry, tc.expected)) {
+				tt.Errorf("Expected:\n\t%v\nGot:\n\t%v", tc.expected, r.Entry)
+			}
+		})
+	}
+}
+
+func TestPGRangeValue(t *testing.T) {
+	halfOpen := timeline.Config{Boundary: timeline.BoundaryHalfOpen}
+	days := timeline.Config{Boundary: timeline.BoundaryClosed, Granularity: 24 * time.Hour}
+	cases := []struct {
+		name     string
+		cfg      timeline.Config
+		value    timeline.Entry
+		expected string
+	}{
+		{
+			"default",
+			timeline.Config{},
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			`["2000-01-01 00:00:00+00:00","2001-01-01 00:00:00+00:00")`,
+		},
+		{
+			"half-open",
+			halfOpen,
+			timeline.Must(halfOpen.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			`["2000-01-01 00:00:00+00:00","2001-01-01 00:00:00+00:00")`,
+		},
+		{
+			"closed",
+			days,
+			timeline.Must(days.ForDateRange(2000, time.January, 1, 2000, time.December, 31)),
+			`["2000-01-01 00:00:00+00:00","2000-12-31 00:00:00+00:00"]`,
+		},
+		{
+			"unbounded upper",
+			halfOpen,
+			timeline.Must(halfOpen.FromStartDate(2000, time.January, 1)),
+			`["2000-01-01 00:00:00+00:00",)`,
+		},
+		{"empty", timeline.Config{}, nil, "empty"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			v, err := timeline.PGRange{Entry: tc.value, Config: tc.cfg}.Value()
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if v != tc.expected {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", tc.expected, v)
+			}
+			r := timeline.PGRange{Config: tc.cfg}
+			if err := r.Scan(v); err != nil {
+				tt.Fatalf("Unexpected error scanning %s: %v", v, err)
+			}
+			if (r.Entry == nil) != (tc.value == nil) || (r.Entry != nil && !testIsSameEntry(r.Entry, tc.value)) {
+				tt.Errorf("Expected round trip to return:\n\t%v\nGot:\n\t%v", tc.value, r.Entry)
+			}
+		})
+	}
+}
+
+func TestPGMultirange(t *testing.T) {
+	m := timeline.PGMultirange{Config: timeline.Config{Boundary: timeline.BoundaryHalfOpen}}
+	err := m.Scan(`{["2004-01-01 00:00:00+00","2005-01-01 00:00:00+00"),["2000-01-01 00:00:00+00","2001-01-01 00:00:00+00"),["2010-01-01 00:00:00+00",)}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := m.Config.New(
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+		timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+	)
+	if !testIsSameTimeline(m.Timeline, expected) {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(m.Timeline))
+	}
+
+	v, err := timeline.PGMultirange{Timeline: expected, Config: m.Config}.Value()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedValue := `{["2000-01-01 00:00:00+00:00","2001-01-01 00:00:00+00:00"),["2004-01-01 00:00:00+00:00","2005-01-01 00:00:00+00:00"),["2010-01-01 00:00:00+00:00",)}`
+	if v != expectedValue {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%s", expectedValue, v)
+	}
+
+	if err := m.Scan("{}"); err != nil || len(m.Timeline) != 0 {
+		t.Errorf("Expected an empty timeline, got %v (%v)", m.Timeline, err)
+	}
+	if err := m.Scan(`{["2000-01-01 00:00:00+00",)`); err == nil {
+		t.Errorf("Expected an error for an unterminated multirange")
+	}
+	if err := m.Scan(`{["2000-01-01 00:00:00+00","2001-01-01 00:00:00+00"]}`); err == nil {
+		t.Errorf("Expected an error for an inclusive upper bound")
+	}
+
+	// the zero value reads and writes the form that PostgreSQL uses by default
+	var zero timeline.PGMultirange
+	if err := zero.Scan(expectedValue); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v, err := zero.Value(); err != nil || v != expectedValue {
+		t.Errorf("Expected:\n\t%s\nGot:\n\t%v (%v)", expectedValue, v, err)
+	}
+}
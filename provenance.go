@@ -0,0 +1,74 @@
+package timeline
+
+import "time"
+
+// ProvenanceSegment represents an entry of a normalized timeline, along with the input entries that
+// contributed to it
+type ProvenanceSegment struct {
+	Entry
+	Sources []Entry
+}
+
+// Provenance maintains a normalized timeline along with the input entries that it was built from, so that the
+// inputs responsible for each of its entries, or for the coverage of a given time, can be determined.
+//
+// The zero value is an empty Provenance that uses the zero Config.  A Provenance is not safe for concurrent use.
+type Provenance struct {
+	cfg    Config
+	tl     Timeline
+	inputs Index
+}
+
+// NewProvenance returns a new Provenance built from the specified input entries, ignoring any invalid entries
+// as for New()
+func NewProvenance(entries ...Entry) *Provenance {
+	return configOf(entries).NewProvenance(entries...)
+}
+
+// NewProvenance returns a new Provenance built from the specified input entries, which are combined according
+// to the Config
+func (c Config) NewProvenance(entries ...Entry) *Provenance {
+	p := &Provenance{cfg: c, inputs: Index{cfg: c}}
+	for _, e := range entries {
+		if c.validateEntry(e) == nil {
+			p.Add(e)
+		}
+	}
+	return p
+}
+
+// Add adds one or more input entries and returns a boolean value indicating whether or not the timeline was
+// modified.  The inputs are retained even if they are already covered by the timeline.
+//
+// If any of the entries are invalid, a *ValidationError is returned and nothing is added.
+func (p *Provenance) Add(entries ...Entry) (bool, error) {
+	changed, err := p.cfg.Add(&p.tl, entries...)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		p.inputs.Insert(e)
+	}
+	return changed, nil
+}
+
+// Timeline returns a copy of the normalized timeline
+func (p *Provenance) Timeline() Timeline {
+	return p.tl.Clone()
+}
+
+// Segments returns the entries of the normalized timeline, each along with the input entries that contributed
+// to it (sorted by start time)
+func (p *Provenance) Segments() []ProvenanceSegment {
+	res := make([]ProvenanceSegment, len(p.tl))
+	for i, e := range p.tl {
+		res[i] = ProvenanceSegment{Entry: e, Sources: p.inputs.Overlapping(e)}
+	}
+	return res
+}
+
+// Explain returns the input entries that cover the specified time (sorted by start time), which is empty if the
+// time is not covered by the timeline
+func (p *Provenance) Explain(t time.Time) []Entry {
+	return p.inputs.Containing(t)
+}
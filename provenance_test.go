@@ -0,0 +1,56 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestProvenance(t *testing.T) {
+	var (
+		a = timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1))
+		b = timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2002, time.January, 1))
+		c = timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1))
+		d = timeline.Must(timeline.ForDateRange(2005, time.January, 1, 2006, time.January, 1))
+		e = timeline.Must(timeline.ForDateRange(2005, time.March, 1, 2005, time.April, 1))
+	)
+	p := timeline.NewProvenance(d, b, a, c)
+	if changed, err := p.Add(e); changed || err != nil {
+		t.Errorf("Expected adding a covered entry to leave the timeline unchanged, got %v (%v)", changed, err)
+	}
+
+	expected := []timeline.ProvenanceSegment{
+		{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2003, time.January, 1)), Sources: []timeline.Entry{a, b, c}},
+		{Entry: d, Sources: []timeline.Entry{d, e}},
+	}
+	got := p.Segments()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d segments, got %d", len(expected), len(got))
+	}
+	for i, seg := range got {
+		if !testIsSameEntry(seg.Entry, expected[i].Entry) || !testIsSameTimeline(seg.Sources, expected[i].Sources) {
+			t.Errorf("Segment %d: expected %s from %s, got %s from %s", i, expected[i].Entry,
+				printTimeline(expected[i].Sources), seg.Entry, printTimeline(seg.Sources))
+		}
+	}
+
+	cases := []struct {
+		name     string
+		value    time.Time
+		expected timeline.Timeline
+	}{
+		{"single source", time.Date(2000, time.February, 1, 0, 0, 0, 0, time.UTC), timeline.Timeline{a}},
+		{"multiple sources", time.Date(2000, time.July, 1, 0, 0, 0, 0, time.UTC), timeline.Timeline{a, b}},
+		{"boundary", time.Date(2002, time.January, 1, 0, 0, 0, 0, time.UTC), timeline.Timeline{b, c}},
+		{"not covered", time.Date(2004, time.January, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"covered entry", time.Date(2005, time.March, 15, 0, 0, 0, 0, time.UTC), timeline.Timeline{d, e}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			if got := p.Explain(tc.value); !testIsSameTimeline(got, tc.expected) {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected), printTimeline(got))
+			}
+		})
+	}
+}
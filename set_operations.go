@@ -0,0 +1,102 @@
+package timeline
+
+// Union returns a new normalized timeline that covers every span of time covered by either tl or other.
+//
+// Both timelines are expected to be normalized (sorted and non-overlapping, as maintained by Add()), which allows
+// the result to be generated with a single merge pass in O(n+m).  Overlapping and adjacent ranges are combined.
+func (tl Timeline) Union(other Timeline) Timeline {
+	return configOf(tl, other).Union(tl, other)
+}
+
+// Intersection returns a new normalized timeline that covers only the spans of time covered by both tl and other.
+//
+// Both timelines are expected to be normalized.  Entries that are merely adjacent do not intersect.
+func (tl Timeline) Intersection(other Timeline) Timeline {
+	return configOf(tl, other).Intersection(tl, other)
+}
+
+// Difference returns a new normalized timeline that covers the spans of time covered by tl but not by other.
+//
+// Both timelines are expected to be normalized.  Entries in tl are truncated or split as necessary.
+func (tl Timeline) Difference(other Timeline) Timeline {
+	return configOf(tl, other).Difference(tl, other)
+}
+
+// SymmetricDifference returns a new normalized timeline that covers the spans of time covered by exactly one of
+// tl and other.
+//
+// Both timelines are expected to be normalized.
+func (tl Timeline) SymmetricDifference(other Timeline) Timeline {
+	return configOf(tl, other).SymmetricDifference(tl, other)
+}
+
+// Union returns a new normalized timeline that covers every span of time covered by either tl1 or tl2.
+//
+// Both timelines are expected to be normalized (sorted and non-overlapping, as maintained by Add()), which allows
+// the result to be generated with a single merge pass in O(n+m).  Overlapping and adjacent ranges are combined.
+func (c Config) Union(tl1, tl2 Timeline) Timeline {
+	return c.sweep(mergeSorted(c.spansOf(tl1), c.spansOf(tl2)))
+}
+
+// Intersection returns a new normalized timeline that covers only the spans of time covered by both tl1 and tl2.
+//
+// Both timelines are expected to be normalized.  Entries that are merely adjacent do not intersect.
+func (c Config) Intersection(tl1, tl2 Timeline) Timeline {
+	var res Timeline
+	for i, j := 0, 0; i < len(tl1) && j < len(tl2); {
+		a, b := c.spanOf(tl1[i]), c.spanOf(tl2[j])
+		st, et := laterOf(a.start, b.start), earlierOf(a.end, b.end)
+		if st.Before(et) {
+			res = append(res, c.derive(span{start: st, end: et}, tl1[i], tl2[j]))
+		}
+		// advance whichever entry ends first, the other one may still intersect subsequent entries
+		if a.end.Before(b.end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+// Difference returns a new normalized timeline that covers the spans of time covered by tl1 but not by tl2.
+//
+// Both timelines are expected to be normalized.  Entries in tl1 are truncated or split as necessary.
+func (c Config) Difference(tl1, tl2 Timeline) Timeline {
+	var res Timeline
+	j := 0
+	for _, e := range tl1 {
+		cur := c.spanOf(e)
+		// skip entries that end before the current one starts
+		for j < len(tl2) && !c.spanOf(tl2[j]).end.After(cur.start) {
+			j++
+		}
+		covered := false
+		for k := j; k < len(tl2); k++ {
+			sub := c.spanOf(tl2[k])
+			if !sub.start.Before(cur.end) {
+				break
+			}
+			if sub.start.After(cur.start) {
+				res = append(res, c.derive(span{start: cur.start, end: sub.start}, e))
+			}
+			if !sub.end.Before(cur.end) {
+				covered = true
+				break
+			}
+			cur.start = sub.end
+		}
+		if !covered {
+			res = append(res, c.derive(cur, e))
+		}
+	}
+	return res
+}
+
+// SymmetricDifference returns a new normalized timeline that covers the spans of time covered by exactly one of
+// tl1 and tl2.
+//
+// Both timelines are expected to be normalized.
+func (c Config) SymmetricDifference(tl1, tl2 Timeline) Timeline {
+	return c.Union(c.Difference(tl1, tl2), c.Difference(tl2, tl1))
+}
@@ -0,0 +1,110 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestSetOperations(t *testing.T) {
+	var (
+		tl1 = timeline.New(
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2002, time.January, 1)),
+			timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+			timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+		)
+		tl2 = timeline.New(
+			timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2003, time.January, 1)),
+			timeline.Must(timeline.ForDateRange(2005, time.January, 1, 2006, time.January, 1)),
+			timeline.Must(timeline.ForDateRange(2011, time.January, 1, 2012, time.January, 1)),
+		)
+	)
+	cases := []struct {
+		name     string
+		op       func(a, b timeline.Timeline) timeline.Timeline
+		a, b     timeline.Timeline
+		expected timeline.Timeline
+	}{
+		{
+			"union",
+			timeline.Timeline.Union,
+			tl1, tl2,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2003, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2006, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+			),
+		},
+		{
+			"union/empty",
+			timeline.Timeline.Union,
+			tl1, timeline.New(),
+			tl1,
+		},
+		{
+			"intersection",
+			timeline.Timeline.Intersection,
+			tl1, tl2,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2002, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2011, time.January, 1, 2012, time.January, 1)),
+			),
+		},
+		{
+			"intersection/open-ended",
+			timeline.Timeline.Intersection,
+			tl1, timeline.New(timeline.Must(timeline.FromStartDate(2004, time.June, 1))),
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2004, time.June, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+			),
+		},
+		{
+			"difference",
+			timeline.Timeline.Difference,
+			tl1, tl2,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2010, time.January, 1, 2011, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2012, time.January, 1)),
+			),
+		},
+		{
+			"difference/reversed",
+			timeline.Timeline.Difference,
+			tl2, tl1,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2005, time.January, 1, 2006, time.January, 1)),
+			),
+		},
+		{
+			"difference/covered",
+			timeline.Timeline.Difference,
+			tl2, timeline.New(timeline.Must(timeline.FromStartDate(2000, time.January, 1))),
+			timeline.New(),
+		},
+		{
+			"symmetric difference",
+			timeline.Timeline.SymmetricDifference,
+			tl1, tl2,
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2006, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2010, time.January, 1, 2011, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2012, time.January, 1)),
+			),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			got := tc.op(tc.a, tc.b)
+			if !testIsSameTimeline(got, tc.expected) {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected), printTimeline(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package timeline
+
+import "time"
+
+// SlotOption defines a function that customizes the behavior of FreeSlots()
+type SlotOption func(*slotOptions)
+
+type slotOptions struct {
+	alignment time.Duration
+	buffer    time.Duration
+}
+
+// SlotAlignment returns a SlotOption that moves the start of each slot forward to the next multiple of d (e.g.
+// 15 * time.Minute), as for time.Time.Truncate(), so that slots only start on those boundaries
+func SlotAlignment(d time.Duration) SlotOption {
+	return func(o *slotOptions) {
+		o.alignment = d
+	}
+}
+
+// SlotBuffer returns a SlotOption that pads each busy entry by d on both sides, so that slots start at least d
+// after the end of the preceding busy entry and end at least d before the start of the following one
+func SlotBuffer(d time.Duration) SlotOption {
+	return func(o *slotOptions) {
+		o.buffer = d
+	}
+}
+
+// FreeSlots returns up to n spans of time within window, in chronological order, that are not covered by any of
+// the busy timelines and last at least minDuration.  If n is zero or negative, all such slots are returned.
+//
+// Each slot covers the whole of the free span of time in which it was found (after alignment), so that it can be
+// shortened as required by the caller.  The busy timelines do not need to be normalized.
+func FreeSlots(busy []Timeline, window Entry, minDuration time.Duration, n int, opts ...SlotOption) Timeline {
+	return configOf(append([]Timeline{{window}}, busy...)...).FreeSlots(busy, window, minDuration, n, opts...)
+}
+
+// FreeSlots returns up to n spans of time within window, in chronological order, that are not covered by any of
+// the busy timelines and last at least minDuration.  If n is zero or negative, all such slots are returned.
+//
+// Each slot covers the whole of the free span of time in which it was found (after alignment), so that it can be
+// shortened as required by the caller.  The busy timelines do not need to be normalized.
+func (c Config) FreeSlots(busy []Timeline, window Entry, minDuration time.Duration, n int, opts ...SlotOption) Timeline {
+	var o slotOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+	var entries []Entry
+	for _, tl := range busy {
+		for _, e := range tl {
+			if o.buffer <= 0 {
+				entries = append(entries, e)
+				continue
+			}
+			s := c.spanOf(e)
+			if !s.start.Equal(minTime) {
+				s.start = s.start.Add(-o.buffer)
+			}
+			if !s.end.Equal(maxTime) {
+				s.end = s.end.Add(o.buffer)
+			}
+			entries = append(entries, c.entry(s))
+		}
+	}
+	var res Timeline
+	for _, g := range c.Gaps(c.sweep(c.sortedSpansOf(entries)), window) {
+		s := c.spanOf(g)
+		if o.alignment > 0 && !s.start.Equal(minTime) {
+			if st := s.start.Truncate(o.alignment); st.Before(s.start) {
+				s.start = st.Add(o.alignment)
+			}
+		}
+		if !s.start.Before(s.end) || s.end.Sub(s.start) < minDuration {
+			continue
+		}
+		res = append(res, c.derive(s, g))
+		if n > 0 && len(res) == n {
+			break
+		}
+	}
+	return res
+}
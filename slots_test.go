@@ -0,0 +1,139 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestFreeSlots(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2000, time.January, 1, h, m, 0, 0, time.UTC)
+	}
+	span := func(sh, sm, eh, em int) timeline.Entry {
+		return timeline.Must(timeline.NewEntry(at(sh, sm), at(eh, em)))
+	}
+	busy := []timeline.Timeline{
+		timeline.New(span(9, 0, 10, 0), span(13, 0, 14, 0)),
+		timeline.New(span(9, 30, 10, 20), span(11, 0, 11, 30), timeline.Must(timeline.NewEntry(at(16, 0), time.Time{}))),
+	}
+	window := span(8, 0, 18, 0)
+	cases := []struct {
+		name        string
+		busy        []timeline.Timeline
+		window      timeline.Entry
+		minDuration time.Duration
+		n           int
+		opts        []timeline.SlotOption
+		expected    timeline.Timeline
+	}{
+		{
+			"no busy timelines",
+			nil,
+			window,
+			time.Hour,
+			0,
+			nil,
+			timeline.New(window),
+		},
+		{
+			"all slots",
+			busy,
+			window,
+			30 * time.Minute,
+			0,
+			nil,
+			timeline.New(span(8, 0, 9, 0), span(10, 20, 11, 0), span(11, 30, 13, 0), span(14, 0, 16, 0)),
+		},
+		{
+			"first n slots",
+			busy,
+			window,
+			30 * time.Minute,
+			2,
+			nil,
+			timeline.New(span(8, 0, 9, 0), span(10, 20, 11, 0)),
+		},
+		{
+			"minimum duration",
+			busy,
+			window,
+			90 * time.Minute,
+			0,
+			nil,
+			timeline.New(span(11, 30, 13, 0), span(14, 0, 16, 0)),
+		},
+		{
+			"alignment",
+			busy,
+			window,
+			30 * time.Minute,
+			0,
+			[]timeline.SlotOption{timeline.SlotAlignment(15 * time.Minute)},
+			timeline.New(span(8, 0, 9, 0), span(10, 30, 11, 0), span(11, 30, 13, 0), span(14, 0, 16, 0)),
+		},
+		{
+			"alignment leaves slot too short",
+			busy,
+			window,
+			40 * time.Minute,
+			0,
+			[]timeline.SlotOption{timeline.SlotAlignment(15 * time.Minute)},
+			timeline.New(span(8, 0, 9, 0), span(11, 30, 13, 0), span(14, 0, 16, 0)),
+		},
+		{
+			"buffer",
+			busy,
+			window,
+			30 * time.Minute,
+			0,
+			[]timeline.SlotOption{timeline.SlotBuffer(10 * time.Minute)},
+			timeline.New(span(8, 0, 8, 50), span(11, 40, 12, 50), span(14, 10, 15, 50)),
+		},
+		{
+			"buffer and alignment",
+			busy,
+			window,
+			30 * time.Minute,
+			0,
+			[]timeline.SlotOption{timeline.SlotBuffer(10 * time.Minute), timeline.SlotAlignment(15 * time.Minute)},
+			timeline.New(span(8, 0, 8, 50), span(11, 45, 12, 50), span(14, 15, 15, 50)),
+		},
+		{
+			"window without an end",
+			busy,
+			timeline.Must(timeline.NewEntry(at(15, 0), time.Time{})),
+			30 * time.Minute,
+			0,
+			nil,
+			timeline.New(span(15, 0, 16, 0)),
+		},
+		{
+			"open ended slot",
+			busy[:1],
+			timeline.Must(timeline.NewEntry(at(13, 30), time.Time{})),
+			30 * time.Minute,
+			0,
+			nil,
+			timeline.New(timeline.Must(timeline.NewEntry(at(14, 0), time.Time{}))),
+		},
+		{
+			"no free slots",
+			busy,
+			span(9, 0, 10, 20),
+			time.Minute,
+			0,
+			nil,
+			nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := timeline.FreeSlots(tc.busy, tc.window, tc.minDuration, tc.n, tc.opts...)
+			if !testIsSameTimeline(got, tc.expected) {
+				t.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected), printTimeline(got))
+			}
+		})
+	}
+}
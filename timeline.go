@@ -1,218 +1,446 @@
 package timeline
 
 import (
+	"sort"
 	"time"
-
-	"github.com/pkg/errors"
 )
 
 // New returns a new Timeline consisting of the specified entries
+//
+// Any invalid entries (i.e. nil entries or those that end before they start) are ignored, use Add() to detect
+// them instead.
 func New(entries ...Entry) Timeline {
-	var tl Timeline
-	tl.Add(entries...)
-	return tl
+	return configOf(entries).New(entries...)
 }
 
 // Timeline represents a slice of Entry instances, sorted by the entries' start time
+//
+// The methods of Timeline interpret entries according to the Config that created the first entry of the timeline
+// (or, if it is empty, of the entries passed to the method), so a timeline built with Config.New() and the
+// entries of the same Config keeps its rules.  The equivalent Config methods can be used to apply different rules.
 type Timeline []Entry
 
 // Add adds one or more new entries to an existing timeline and returns a boolean value indicating
 // whether or not the timeline was modified
-func (tl *Timeline) Add(entries ...Entry) bool {
-	updated := false
-	for _, e := range entries {
-		if tl.addEntry(e) {
-			updated = true
-		}
-	}
-	return updated
+//
+// When adding multiple entries, the new entries are sorted and then merged with the existing ones in a
+// single pass, which is O(n + m log m) rather than adding each entry individually.
+//
+// If any of the entries are invalid, or the timeline is found not to be normalized, a *ValidationError is
+// returned and the timeline is not modified.
+func (tl *Timeline) Add(entries ...Entry) (bool, error) {
+	return configOf(*tl, entries).Add(tl, entries...)
 }
 
-// Normalize sorts the timeline entries by start date and combines any overlapping ranges
+// Normalize sorts the timeline entries by start date and combines any overlapping or adjacent ranges
 //
-// This process can be expensive - on the order of O(n^2) - for highly denormalized timeline entries and
-// is intended only for cases where there is an existing []Entry that needs to be normalized.
+// The entries are sorted and then combined in a single pass, so this process is O(n log n).  Any invalid
+// entries (i.e. nil entries or those that end before they start) are removed, as for New().
 func (tl *Timeline) Normalize() {
+	configOf(*tl).Normalize(tl)
+}
+
+// Remove removes the spans of time covered by one or more entries from an existing timeline and returns a
+// boolean value indicating whether or not the timeline was modified
+//
+// Existing entries that partially overlap a removed range are truncated, and entries that completely contain a
+// removed range are split in two.
+//
+// If any of the entries are invalid, a *ValidationError is returned and the timeline is not modified.
+func (tl *Timeline) Remove(entries ...Entry) (bool, error) {
+	return configOf(*tl, entries).Remove(tl, entries...)
+}
+
+// Contains determines whether or not the specified time falls within one of the timeline entries and,
+// if it does, returns the start and end of the entry
+//
+// The timeline is expected to be normalized, which allows the entry to be located with a binary search.
+func (tl Timeline) Contains(t time.Time) (bool, time.Time, time.Time) {
+	return configOf(tl).Contains(tl, t)
+}
+
+// Intersect compares two Entry items and returns an IntersectionType value that indicates how the second
+// "new" time span intersects with the first "reference" one, according to the Config of the reference entry
+func Intersect(refEntry, newEntry Entry) IntersectionType {
+	return configOf(Timeline{refEntry}).Intersect(refEntry, newEntry)
+}
+
+// New returns a new Timeline consisting of the specified entries, combined according to c
+//
+// Any invalid entries (i.e. nil entries or those that end before they start) are ignored, use Add() to detect
+// them instead.
+func (c Config) New(entries ...Entry) Timeline {
+	var tl Timeline
+	c.Add(&tl, c.validEntries(entries)...)
+	return tl
+}
+
+// Add adds one or more new entries to an existing timeline and returns a boolean value indicating
+// whether or not the timeline was modified
+//
+// When adding multiple entries, the new entries are sorted and then merged with the existing ones in a
+// single pass, which is O(n + m log m) rather than adding each entry individually.
+//
+// If any of the entries are invalid, or the timeline is found not to be normalized, a *ValidationError is
+// returned and the timeline is not modified.
+func (c Config) Add(tl *Timeline, entries ...Entry) (bool, error) {
+	if err := c.validateEntries(entries); err != nil {
+		return false, err
+	}
+	switch len(entries) {
+	case 0:
+		return false, nil
+	case 1:
+		return c.addEntry(tl, entries[0], nil)
+	}
+	// the existing entries are visited anyway, so make sure that they are normalized
+	if err := c.Validate(*tl); err != nil {
+		return false, err
+	}
+	ntl := c.sweep(mergeSorted(c.spansOf(*tl), c.sortedSpansOf(entries)))
+	if c.sameSpans(ntl, *tl) {
+		return false, nil
+	}
+	*tl = ntl
+	return true, nil
+}
+
+// Normalize sorts the timeline entries by start date and combines any overlapping or adjacent ranges
+//
+// The entries are sorted and then combined in a single pass, so this process is O(n log n).  Any invalid
+// entries (i.e. nil entries or those that end before they start) are removed, as for New().
+func (c Config) Normalize(tl *Timeline) {
 	if len(*tl) > 0 {
-		// since all of the logic for generating non-overlapping ranges in start date order is already
-		// implemented by addEntry(), we can just create a brand new timeline, add each of our entries
-		// to it, then replace this timeline w/ the new one wholesale
-		ntl := Timeline{}
-		for _, e := range *tl {
-			ntl.addEntry(e)
+		*tl = c.sweep(c.sortedSpansOf(c.validEntries(*tl)))
+	}
+}
+
+// spanEntry pairs an entry with its span so that the span does not need to be recomputed when sorting
+type spanEntry struct {
+	entry Entry
+	span  span
+}
+
+// spansOf returns the spans of the specified entries, in the same order
+func (c Config) spansOf(entries []Entry) []spanEntry {
+	res := make([]spanEntry, len(entries))
+	for i, e := range entries {
+		res[i] = spanEntry{entry: e, span: c.spanOf(e)}
+	}
+	return res
+}
+
+// sortedSpansOf returns the spans of the specified entries, sorted according to spanLess
+func (c Config) sortedSpansOf(entries []Entry) []spanEntry {
+	spans := c.spansOf(entries)
+	// sort indexes rather than the spans themselves since they are much cheaper to swap, using the index to
+	// break ties so that the sort is stable
+	idx := make([]int, len(spans))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		a, b := spans[idx[i]].span, spans[idx[j]].span
+		if !a.equal(b) {
+			return spanLess(a, b)
+		}
+		return idx[i] < idx[j]
+	})
+	res := make([]spanEntry, len(spans))
+	for i, j := range idx {
+		res[i] = spans[j]
+	}
+	return res
+}
+
+// spanLess reports whether a should be sorted before b, i.e. it starts earlier or starts at the same time
+// and ends later
+func spanLess(a, b span) bool {
+	if !a.start.Equal(b.start) {
+		return a.start.Before(b.start)
+	}
+	return a.end.After(b.end)
+}
+
+// mergeSorted merges two slices that are sorted according to spanLess into a new sorted slice
+func mergeSorted(a, b []spanEntry) []spanEntry {
+	res := make([]spanEntry, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if spanLess(b[j].span, a[i].span) {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	res = append(res, a[i:]...)
+	return append(res, b[j:]...)
+}
+
+// sweep combines any overlapping or adjacent entries in a slice that is sorted according to spanLess and
+// returns the resulting normalized timeline
+//
+// Entries that do not need to be combined with any others are retained as-is, as are adjacent entries if
+// KeepAdjacent is set.  Entries that are covered by another one are combined with it, even though its range is
+// unchanged, so that a Merger sees every source.
+func (c Config) sweep(sorted []spanEntry) Timeline {
+	res := make(Timeline, 0, len(sorted))
+	for i := 0; i < len(sorted); {
+		cur := sorted[i].span
+		j := i + 1
+		for ; j < len(sorted) && c.combines(cur, sorted[j].span); j++ {
+			// overlapping or adjacent, extend the current range if necessary
+			if sorted[j].span.end.After(cur.end) {
+				cur.end = sorted[j].span.end
+			}
+		}
+		if j > i+1 {
+			sources := make([]Entry, 0, j-i)
+			for _, se := range sorted[i:j] {
+				sources = append(sources, se.entry)
+			}
+			res = append(res, c.derive(cur, sources...))
+		} else {
+			res = append(res, sorted[i].entry)
+		}
+		i = j
+	}
+	return res
+}
+
+// combines reports whether next, which does not start before cur, should be combined with it
+func (c Config) combines(cur, next span) bool {
+	if c.KeepAdjacent {
+		return next.start.Before(cur.end)
+	}
+	return !next.start.After(cur.end)
+}
+
+// sameSpans reports whether tl1 and tl2 consist of entries with the same spans
+func (c Config) sameSpans(tl1, tl2 Timeline) bool {
+	if len(tl1) != len(tl2) {
+		return false
+	}
+	for i, e := range tl1 {
+		if !c.spanOf(e).equal(c.spanOf(tl2[i])) {
+			return false
 		}
-		*tl = []Entry(ntl)
 	}
+	return true
 }
 
-func (tl *Timeline) addEntry(entry Entry) bool {
+// addEntry adds a single entry to the timeline and, if cs is non-nil, records the existing entries that were
+// replaced along with the resulting entry in it
+//
+// A *ValidationError is returned if any of the existing entries that it visits are found not to be normalized, in
+// which case the timeline is not modified.
+func (c Config) addEntry(tl *Timeline, entry Entry, cs *ChangeSet) (bool, error) {
 	// no work to do if this is the first entry, add it and return
 	if len(*tl) == 0 {
 		*tl = append(*tl, entry)
-		return true
+		cs.record(c, entry)
+		return true, nil
+	}
+	ns := c.spanOf(entry)
+	// step thru existing timeline, skipping any entries that end before the new one starts since they cannot
+	// intersect it
+	i, err := c.search(*tl, ns.start, false)
+	if err != nil {
+		return false, err
 	}
-	// step thru existing timeline
-	for i, refEntry := range *tl {
-		switch Intersect(refEntry, entry) {
+	for ; i < len(*tl); i++ {
+		rs, err := c.spanAt(*tl, i)
+		if err != nil {
+			return false, err
+		}
+		itype := c.mergeType(rs, ns)
+		if itype == IntersectionTypeAdjacent && !ns.start.Before(rs.start) {
+			// new entry starts at the end of the existing entry and may extend over subsequent entries, so
+			// handle it the same as an overlap of the end
+			itype = IntersectionTypeEndOverlap
+		}
+		switch itype {
 		case IntersectionTypeSame, IntersectionTypeWithin:
 			// specified date range is already covered, no-op
-			return false
+			return false, nil
 
 		case IntersectionTypeNone:
 			// if no intersection and the new entry's start date is before the reference entry, insert at i
-			if entry.StartTime().Before(refEntry.StartTime()) {
+			if ns.start.Before(rs.start) {
 				*tl = append(*tl, nil)
 				copy((*tl)[i+1:], (*tl)[i:])
 				(*tl)[i] = entry
-				return true
-			}
-
-		case IntersectionTypeAdjacent:
-			// new entry is adjacent to existing entry
-			// . update entry at i w/ new one covering the combined range
-			st := entry.StartTime()
-			if est := refEntry.StartTime(); est.Before(st) {
-				st = est
-			}
-			et, _ := entry.EndTime()
-			if eet, _ := refEntry.EndTime(); eet.After(et) {
-				et = eet
+				cs.record(c, entry)
+				return true, nil
 			}
-			ne, _ := NewEntry(st, et)
-			(*tl)[i] = ne
-			return true
 
-		case IntersectionTypeStartOverlap:
-			// new entry overlaps start of existing entry
+		case IntersectionTypeAdjacent, IntersectionTypeStartOverlap:
+			// new entry is adjacent to or overlaps start of existing entry
 			// . update entry at i w/ new one w/ the new start and the existing end
-			et, _ := refEntry.EndTime()
-			ne, _ := NewEntry(entry.StartTime(), et)
-			(*tl)[i] = ne
-			return true
+			replaced := (*tl)[i]
+			(*tl)[i] = c.derive(span{start: ns.start, end: rs.end}, entry, replaced)
+			cs.record(c, (*tl)[i], replaced)
+			return true, nil
 
 		case IntersectionTypeCover, IntersectionTypeEndOverlap:
 			// new entry covers or overlaps end of existing entry
-			// . update entry at i w/ new one w/ the existing start and the new end
-			// . remove any subsequent entries that are covered by the new range
-			st := refEntry.StartTime()
-			if nst := entry.StartTime(); nst.Before(st) {
-				st = nst
-			}
-			et, _ := entry.EndTime()
-			ne, _ := NewEntry(st, et)
-			for j, done := i+1, false; !done && j < len(*tl); j++ {
-				ee := (*tl)[j]
-				itype := Intersect(ee, ne)
-				switch itype {
+			// . find any subsequent entries that are covered by or overlap the new range
+			// . update entry at i w/ new one w/ the existing start and the new end (or the end of the last
+			//   overlapping entry)
+			// . remove the subsequent entries
+			ms := span{start: earlierOf(rs.start, ns.start), end: ns.end}
+			j := i + 1
+			for done := false; !done && j < len(*tl); {
+				es, err := c.spanAt(*tl, j)
+				if err != nil {
+					return false, err
+				}
+				switch c.mergeType(es, ms) {
 				case IntersectionTypeNone:
 					done = true
 
 				case IntersectionTypeCover:
-					// remove
-					l := len(*tl)
-					copy((*tl)[j:], (*tl)[j+1:])
-					(*tl)[l-1] = nil
-					*tl = (*tl)[:l-1]
-					j--
+					j++
 
 				case IntersectionTypeStartOverlap, IntersectionTypeAdjacent:
 					// save the end of this entry
-					et, _ = ee.EndTime()
-					// remove
-					l := len(*tl)
-					copy((*tl)[j:], (*tl)[j+1:])
-					(*tl)[l-1] = nil
-					*tl = (*tl)[:l-1]
-					j--
-
-				case IntersectionTypeSame, IntersectionTypeWithin, IntersectionTypeEndOverlap:
-					// TODO: remove this panic after everything works
-					panic(errors.Errorf("Unexpected intersection type %s at index %d (%s)", itype, j, (*tl)[j]))
+					ms.end = es.end
+					j++
+
+				default:
+					// the new range cannot start after an existing entry unless the existing entries overlap
+					return false, &ValidationError{Index: j, Entry: (*tl)[j], Err: ErrOverlappingEntries}
 				}
 			}
-			// shift end date of the new entry if we covered >1 existing entry
-			if ed, _ := ne.EndTime(); ed.Before(et) {
-				ne, _ = NewEntry(st, et)
+			replaced := append(Timeline{}, (*tl)[i:j]...)
+			sources := append(Timeline{entry}, replaced...)
+			if rs.start.Before(ns.start) {
+				// keep the sources in chronological order
+				sources[0], sources[1] = sources[1], sources[0]
 			}
-			(*tl)[i] = ne
-			return true
+			(*tl)[i] = c.derive(ms, sources...)
+			*tl = append((*tl)[:i+1], (*tl)[j:]...)
+			cs.record(c, (*tl)[i], replaced...)
+			return true, nil
 		}
 	}
 	// new start is later than any existing start w/ no intersection, add to end
 	*tl = append(*tl, entry)
-	return true
+	cs.record(c, entry)
+	return true, nil
 }
 
-// Contains determines whether or not the specified time falls within one of the timeline entries and,
-// if it does, returns the start and end of the entry
-func (tl Timeline) Contains(t time.Time) (bool, time.Time, time.Time) {
-	if t.IsZero() {
-		return false, time.Time{}, time.Time{}
-	}
-	for _, e := range tl {
-		sd := e.StartTime()
-		ed, hasEnd := e.EndTime()
-		if sd.After(t) {
-			continue
-		}
-		if sd.Equal(t) {
-			return true, sd, ed
+// Remove removes the spans of time covered by one or more entries from an existing timeline and returns a
+// boolean value indicating whether or not the timeline was modified
+//
+// Existing entries that partially overlap a removed range are truncated, and entries that completely contain a
+// removed range are split in two.
+//
+// If any of the entries are invalid, or any of the existing entries that are visited are found not to be
+// normalized, a *ValidationError is returned and the timeline is not modified.
+func (c Config) Remove(tl *Timeline, entries ...Entry) (bool, error) {
+	if err := c.validateEntries(entries); err != nil {
+		return false, err
+	}
+	// work on a copy, so that the timeline is not left partially modified if an error is found
+	ntl := append(Timeline(nil), *tl...)
+	updated := false
+	for _, e := range entries {
+		ok, err := c.removeEntry(&ntl, e)
+		if err != nil {
+			return false, err
 		}
-		if !hasEnd || !t.After(ed) {
-			return true, sd, ed
+		if ok {
+			updated = true
 		}
 	}
-	return false, time.Time{}, time.Time{}
+	if updated {
+		*tl = ntl
+	}
+	return updated, nil
 }
 
-// Intersect compares two Entry items and returns an IntersectionType value that indicates how the second
-// "new" time span intersects with the first "reference" one
-func Intersect(refEntry, newEntry Entry) IntersectionType {
-	var (
-		refStart          = refEntry.StartTime()
-		refEnd, refHasEnd = refEntry.EndTime()
-		newStart          = newEntry.StartTime()
-		newEnd, newHasEnd = newEntry.EndTime()
-	)
-	if !refHasEnd {
-		refEnd = EndOfTime()
-	}
-	if !newHasEnd {
-		newEnd = EndOfTime()
-	}
-	// newStart < refStart
-	if newStart.Before(refStart) {
-		if newEnd.Before(refStart) {
-			return IntersectionTypeNone
-		}
-		if newEnd.Equal(refStart) {
-			return IntersectionTypeAdjacent
-		}
-		if newEnd.After(refEnd) {
-			return IntersectionTypeCover
-		}
-		return IntersectionTypeStartOverlap
+func (c Config) removeEntry(tl *Timeline, entry Entry) (bool, error) {
+	updated := false
+	ns := c.spanOf(entry)
+	// step thru existing timeline, skipping any entries that end before the removed range starts
+	i, err := c.search(*tl, ns.start, false)
+	if err != nil {
+		return false, err
 	}
-	// newStart > refStart
-	if newStart.After(refStart) {
-		if newStart.After(refEnd) {
-			return IntersectionTypeNone
+	for ; i < len(*tl); i++ {
+		rs, err := c.spanAt(*tl, i)
+		if err != nil {
+			return false, err
 		}
-		if newStart.Equal(refEnd) {
-			return IntersectionTypeAdjacent
-		}
-		if !newEnd.After(refEnd) {
-			return IntersectionTypeWithin
+		switch intersectSpans(rs, ns) {
+		case IntersectionTypeNone, IntersectionTypeAdjacent:
+			// if no overlap and the removed range starts before the reference entry, there is nothing more to do
+			if ns.start.Before(rs.start) {
+				return updated, nil
+			}
+
+		case IntersectionTypeSame, IntersectionTypeCover:
+			// removed range covers the existing entry, remove it
+			tl.removeAt(i)
+			i--
+			updated = true
+
+		case IntersectionTypeWithin:
+			// removed range is within existing entry
+			// . keep the portion of the existing entry before the removed range, if any
+			// . keep the portion of the existing entry after the removed range, if any
+			var parts []Entry
+			if ns.start.After(rs.start) {
+				parts = append(parts, c.derive(span{start: rs.start, end: ns.start}, (*tl)[i]))
+			}
+			if ns.end.Before(rs.end) {
+				parts = append(parts, c.derive(span{start: ns.end, end: rs.end}, (*tl)[i]))
+			}
+			switch len(parts) {
+			case 0:
+				tl.removeAt(i)
+			case 1:
+				(*tl)[i] = parts[0]
+			case 2:
+				*tl = append(*tl, nil)
+				copy((*tl)[i+1:], (*tl)[i:])
+				(*tl)[i], (*tl)[i+1] = parts[0], parts[1]
+			}
+			return true, nil
+
+		case IntersectionTypeStartOverlap:
+			// removed range overlaps start of existing entry
+			// . update entry at i w/ new one w/ the end of the removed range and the existing end
+			if !ns.end.Before(rs.end) {
+				tl.removeAt(i)
+			} else {
+				(*tl)[i] = c.derive(span{start: ns.end, end: rs.end}, (*tl)[i])
+			}
+			return true, nil
+
+		case IntersectionTypeEndOverlap:
+			// removed range overlaps end of existing entry
+			// . update entry at i w/ new one w/ the existing start and the start of the removed range
+			// . subsequent entries may also overlap the removed range, so keep going
+			if ns.start.After(rs.start) {
+				(*tl)[i] = c.derive(span{start: rs.start, end: ns.start}, (*tl)[i])
+			} else {
+				tl.removeAt(i)
+				i--
+			}
+			updated = true
 		}
-		return IntersectionTypeEndOverlap
 	}
-	// newStart == refStart
-	if newEnd.Before(refEnd) {
-		return IntersectionTypeWithin
-	}
-	if newEnd.After(refEnd) {
-		return IntersectionTypeEndOverlap
-	}
-	// newStart == refStart && newEnd == refEnd
-	return IntersectionTypeSame
+	return updated, nil
+}
+
+// removeAt removes the entry at index i from the timeline
+func (tl *Timeline) removeAt(i int) {
+	l := len(*tl)
+	copy((*tl)[i:], (*tl)[i+1:])
+	(*tl)[l-1] = nil
+	*tl = (*tl)[:l-1]
 }
@@ -2,10 +2,11 @@ package timeline_test
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 	"time"
 
-	"github.com/code-willing/timeline"
+	"github.com/code-willing/go-timeline"
 )
 
 func TestIntersect(t *testing.T) {
@@ -349,6 +350,22 @@ func TestAddEntry(t *testing.T) {
 			),
 			true,
 		},
+		{
+			"existing timeline/contiguous new entry/bridge existing",
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+			),
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2004, time.January, 1)),
+			},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+			),
+			true,
+		},
 		{
 			"existing timeline/overlapping new entry/merge all existing",
 			timeline.New(
@@ -382,7 +399,10 @@ func TestAddEntry(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(tt *testing.T) {
-			changed := tc.value.Add(tc.newEntries...)
+			changed, err := tc.value.Add(tc.newEntries...)
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
 			if changed != tc.shouldChange {
 				tt.Errorf("Expected 'changed' to be:\n\t%v\nGot:\n\t%v", tc.shouldChange, changed)
 			}
@@ -393,6 +413,304 @@ func TestAddEntry(t *testing.T) {
 	}
 }
 
+func TestRemoveEntry(t *testing.T) {
+	existing := func() timeline.Timeline {
+		return timeline.New(
+			timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+			timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+			timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+		)
+	}
+	cases := []struct {
+		name         string
+		value        timeline.Timeline
+		removed      []timeline.Entry
+		expected     timeline.Timeline
+		shouldChange bool
+	}{
+		{
+			"empty timeline",
+			timeline.New(),
+			[]timeline.Entry{
+				timeline.Must(timeline.FromStartDate(2000, time.January, 1)),
+			},
+			timeline.New(),
+			false,
+		},
+		{
+			"no overlap",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2004, time.January, 1)),
+			},
+			existing(),
+			false,
+		},
+		{
+			"same as existing",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+			},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+			),
+			true,
+		},
+		{
+			"within existing/split",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2004, time.March, 1, 2004, time.June, 1)),
+			},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2004, time.March, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.June, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+			),
+			true,
+		},
+		{
+			"within open-ended existing/split",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2011, time.January, 1, 2012, time.January, 1)),
+			},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2010, time.January, 1, 2011, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2012, time.January, 1)),
+			),
+			true,
+		},
+		{
+			"open-ended within open-ended existing/truncate",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.FromStartDate(2011, time.January, 1)),
+			},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2010, time.January, 1, 2011, time.January, 1)),
+			),
+			true,
+		},
+		{
+			"overlap start of existing",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2003, time.January, 1, 2004, time.June, 1)),
+			},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+				timeline.Must(timeline.ForDateRange(2004, time.June, 1, 2005, time.January, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+			),
+			true,
+		},
+		{
+			"overlap multiple existing",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2010, time.June, 1)),
+			},
+			timeline.New(
+				timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.June, 1)),
+				timeline.Must(timeline.FromStartDate(2010, time.June, 1)),
+			),
+			true,
+		},
+		{
+			"open-ended covers all existing",
+			existing(),
+			[]timeline.Entry{
+				timeline.Must(timeline.FromStartDate(1999, time.January, 1)),
+			},
+			timeline.New(),
+			true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			changed, err := tc.value.Remove(tc.removed...)
+			if err != nil {
+				tt.Fatalf("Unexpected error: %v", err)
+			}
+			if changed != tc.shouldChange {
+				tt.Errorf("Expected 'changed' to be:\n\t%v\nGot:\n\t%v", tc.shouldChange, changed)
+			}
+			if !testIsSameTimeline(tc.value, tc.expected) {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(tc.expected), printTimeline(tc.value))
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	// compare the results of normalizing a randomly generated slice of entries against adding the entries to
+	// a timeline one at a time
+	rnd := rand.New(rand.NewSource(42))
+	base := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for n := 0; n < 100; n++ {
+		var (
+			entries  = make([]timeline.Entry, 50)
+			expected timeline.Timeline
+		)
+		for i := range entries {
+			st := base.AddDate(0, 0, rnd.Intn(365))
+			et := time.Time{}
+			if rnd.Intn(10) > 0 {
+				et = st.AddDate(0, 0, 1+rnd.Intn(10))
+			}
+			entries[i] = timeline.Must(timeline.NewEntry(st, et))
+			expected.Add(entries[i])
+		}
+		got := timeline.Timeline(append([]timeline.Entry(nil), entries...))
+		got.Normalize()
+		if !testIsSameTimeline(got, expected) {
+			t.Fatalf("Normalize(): expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(got))
+		}
+		got = timeline.New(entries...)
+		if !testIsSameTimeline(got, expected) {
+			t.Fatalf("New(): expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(got))
+		}
+		if changed, _ := got.Add(entries...); changed {
+			t.Fatalf("Add(): expected no change when re-adding existing entries")
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tl := timeline.New(
+		timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)),
+		timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1)),
+		timeline.Must(timeline.FromStartDate(2010, time.January, 1)),
+	)
+	cases := []struct {
+		name     string
+		value    time.Time
+		expected timeline.Entry
+	}{
+		{"zero time", time.Time{}, nil},
+		{"before first entry", time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"start of entry", time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), tl[0]},
+		{"within entry", time.Date(2004, time.June, 1, 0, 0, 0, 0, time.UTC), tl[1]},
+		{"end of entry", time.Date(2005, time.January, 1, 0, 0, 0, 0, time.UTC), tl[1]},
+		{"between entries", time.Date(2005, time.January, 2, 0, 0, 0, 0, time.UTC), nil},
+		{"within open-ended entry", time.Date(2050, time.January, 1, 0, 0, 0, 0, time.UTC), tl[2]},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			ok, st, et := tl.Contains(tc.value)
+			if ok != (tc.expected != nil) {
+				tt.Fatalf("Expected 'ok' to be:\n\t%v\nGot:\n\t%v", tc.expected != nil, ok)
+			}
+			if ok && !testIsSameEntry(tc.expected, timeline.Must(timeline.NewEntry(st, et))) {
+				tt.Errorf("Expected:\n\t%s\nGot:\n\t[%s .. %s]", tc.expected, st, et)
+			}
+		})
+	}
+}
+
+func TestOpenEnded(t *testing.T) {
+	st := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	closed := timeline.Must(timeline.NewEntry(st, timeline.EndOfTime()))
+	if _, hasEnd := closed.EndTime(); !hasEnd {
+		t.Errorf("Expected an entry ending at EndOfTime() to have an end date")
+	}
+	open := timeline.Must(timeline.NewEntry(st, time.Time{}))
+	if _, hasEnd := open.EndTime(); hasEnd {
+		t.Errorf("Expected an entry without an end date to be open-ended")
+	}
+	if got := timeline.Intersect(closed, open); got != timeline.IntersectionTypeEndOverlap {
+		t.Errorf("Expected open-ended entry to extend beyond EndOfTime(), got %q", got)
+	}
+
+	// changing the sentinel does not affect existing entries
+	orig := timeline.EndOfTime()
+	timeline.SetEndOfTime(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC))
+	defer timeline.SetEndOfTime(orig)
+	if _, hasEnd := closed.EndTime(); !hasEnd {
+		t.Errorf("Expected closed entry to retain its end date after SetEndOfTime()")
+	}
+	if _, hasEnd := open.EndTime(); hasEnd {
+		t.Errorf("Expected open-ended entry to remain open after SetEndOfTime()")
+	}
+
+	// merging entries retains whether or not the result has an end date
+	tl := timeline.New(
+		timeline.Must(timeline.NewEntry(st.AddDate(-1, 0, 0), st.AddDate(1, 0, 0))),
+		closed,
+	)
+	if et, hasEnd := tl[0].EndTime(); len(tl) != 1 || !hasEnd || !et.Equal(orig) {
+		t.Errorf("Expected a single entry ending at %s, got %s", orig, printTimeline(tl))
+	}
+	tl.Add(timeline.Must(timeline.NewEntry(st.AddDate(-2, 0, 0), time.Time{})))
+	if _, hasEnd := tl[0].EndTime(); len(tl) != 1 || hasEnd {
+		t.Errorf("Expected a single open-ended entry, got %s", printTimeline(tl))
+	}
+}
+
+func TestUnboundedStart(t *testing.T) {
+	var (
+		d2000  = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+		d2010  = time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC)
+		before = timeline.Must(timeline.NewEntry(time.Time{}, d2000))
+		always = timeline.Must(timeline.NewEntry(time.Time{}, time.Time{}))
+		later  = timeline.Must(timeline.ForDateRange(1990, time.January, 1, 2010, time.January, 1))
+	)
+	if s := fmt.Sprint(before); s != "(- .. 2000-01-01T00:00:00Z]" {
+		t.Errorf("Unexpected String(): %s", s)
+	}
+	if s := fmt.Sprint(always); s != "(- .. -)" {
+		t.Errorf("Unexpected String(): %s", s)
+	}
+
+	intersections := []struct {
+		ref, new timeline.Entry
+		expected timeline.IntersectionType
+	}{
+		{before, later, timeline.IntersectionTypeEndOverlap},
+		{later, before, timeline.IntersectionTypeStartOverlap},
+		{before, always, timeline.IntersectionTypeEndOverlap},
+		{always, before, timeline.IntersectionTypeWithin},
+		{always, always, timeline.IntersectionTypeSame},
+		{before, timeline.Must(timeline.FromStartDate(2000, time.January, 1)), timeline.IntersectionTypeAdjacent},
+	}
+	for _, tc := range intersections {
+		if got := timeline.Intersect(tc.ref, tc.new); got != tc.expected {
+			t.Errorf("Intersect(%s, %s): expected %q, got %q", tc.ref, tc.new, tc.expected, got)
+		}
+	}
+
+	tl := timeline.New(later, before)
+	expected := timeline.Timeline{timeline.Must(timeline.NewEntry(time.Time{}, d2010))}
+	if !testIsSameTimeline(tl, expected) {
+		t.Fatalf("Expected:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+	for _, v := range []time.Time{time.Date(-100, time.January, 1, 0, 0, 0, 0, time.UTC), d2000, d2010} {
+		if ok, st, _ := tl.Contains(v); !ok || !st.IsZero() {
+			t.Errorf("Expected %s to be contained in an entry without a start, got %v, %s", v, ok, st)
+		}
+	}
+	if ok, _, _ := tl.Contains(d2010.Add(1)); ok {
+		t.Errorf("Expected %s not to be contained", d2010.Add(1))
+	}
+
+	tl.Remove(timeline.Must(timeline.ForDateRange(1980, time.January, 1, 1990, time.January, 1)))
+	expected = timeline.Timeline{
+		timeline.Must(timeline.NewEntry(time.Time{}, time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC))),
+		timeline.Must(timeline.ForDateRange(1990, time.January, 1, 2010, time.January, 1)),
+	}
+	if !testIsSameTimeline(tl, expected) {
+		t.Errorf("Expected after remove:\n\t%s\nGot:\n\t%s", printTimeline(expected), printTimeline(tl))
+	}
+}
+
 func testIsSameTimeline(tl1, tl2 timeline.Timeline) bool {
 	if len(tl1) != len(tl2) {
 		return false
@@ -418,3 +736,79 @@ func testIsSameEntry(e1, e2 timeline.Entry) bool {
 func printTimeline(tl timeline.Timeline) string {
 	return fmt.Sprintf("%v", tl)
 }
+
+// benchmarkTimeline returns a normalized timeline with n one-day entries separated by one-day gaps
+func benchmarkTimeline(n int) timeline.Timeline {
+	tl := make(timeline.Timeline, n)
+	st := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := range tl {
+		tl[i] = timeline.Must(timeline.NewEntry(st, st.AddDate(0, 0, 1)))
+		st = st.AddDate(0, 0, 2)
+	}
+	return tl
+}
+
+// containsLinear is the original linear scan implementation of Timeline.Contains, used as a baseline
+func containsLinear(tl timeline.Timeline, t time.Time) bool {
+	for _, e := range tl {
+		ed, hasEnd := e.EndTime()
+		if !e.StartTime().After(t) && (!hasEnd || !t.After(ed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func BenchmarkContains(b *testing.B) {
+	for _, n := range []int{100, 10000, 100000} {
+		tl := benchmarkTimeline(n)
+		t := tl[n-1].StartTime()
+		b.Run(fmt.Sprintf("binary/%d", n), func(bb *testing.B) {
+			for i := 0; i < bb.N; i++ {
+				tl.Contains(t)
+			}
+		})
+		b.Run(fmt.Sprintf("linear/%d", n), func(bb *testing.B) {
+			for i := 0; i < bb.N; i++ {
+				containsLinear(tl, t)
+			}
+		})
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	for _, n := range []int{100, 10000, 100000} {
+		tl := benchmarkTimeline(n)
+		// extend the end of the last entry so that no entries need to be shifted
+		last := tl[n-1]
+		ed, _ := last.EndTime()
+		e := timeline.Must(timeline.NewEntry(last.StartTime(), ed.Add(time.Hour)))
+		b.Run(fmt.Sprintf("%d", n), func(bb *testing.B) {
+			for i := 0; i < bb.N; i++ {
+				bb.StopTimer()
+				cp := make(timeline.Timeline, len(tl))
+				copy(cp, tl)
+				bb.StartTimer()
+				cp.Add(e)
+			}
+		})
+	}
+}
+
+func BenchmarkNormalize(b *testing.B) {
+	for _, n := range []int{100, 10000, 100000} {
+		rnd := rand.New(rand.NewSource(42))
+		base := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+		entries := make([]timeline.Entry, n)
+		for i := range entries {
+			st := base.Add(time.Duration(rnd.Intn(n*24)) * time.Hour)
+			entries[i] = timeline.Must(timeline.NewEntry(st, st.Add(time.Duration(1+rnd.Intn(48))*time.Hour)))
+		}
+		b.Run(fmt.Sprintf("%d", n), func(bb *testing.B) {
+			for i := 0; i < bb.N; i++ {
+				tl := timeline.Timeline(append([]timeline.Entry(nil), entries...))
+				tl.Normalize()
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package timeline
+
+import "fmt"
+
+// ValidationError describes an invalid entry, either within a timeline that is not normalized or among the
+// entries passed to a function such as Add()
+type ValidationError struct {
+	// Index is the index of the invalid entry within the timeline or the list of entries
+	Index int
+	// Entry is the invalid entry
+	Entry Entry
+	// Err describes the problem, and is one of ErrNilEntry, ErrInvalidTimelineOrder, ErrUnsortedTimeline,
+	// ErrOverlappingEntries or ErrAdjacentEntries
+	Err error
+}
+
+// Error implements error for ValidationError values
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Invalid timeline entry at index %d (%v): %s", e.Index, e.Entry, e.Err)
+}
+
+// Unwrap returns the error that describes the problem, so that it can be checked with errors.Is()
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks that the timeline is normalized, i.e. that it does not contain any nil entries or entries
+// that end before they start, and that the entries are sorted by start time and neither overlap nor (unless
+// KeepAdjacent is set) are adjacent to one another.
+//
+// Timelines that are only modified by the methods of this package are always normalized, but timelines that
+// are built by hand (e.g. using a slice literal) may not be.  The first problem found is returned as a
+// *ValidationError.
+func (tl Timeline) Validate() error {
+	return configOf(tl).Validate(tl)
+}
+
+// Validate checks that the timeline is normalized according to the Config, as for Timeline.Validate()
+func (c Config) Validate(tl Timeline) error {
+	var prev span
+	for i, e := range tl {
+		if err := c.validateEntry(e); err != nil {
+			return &ValidationError{Index: i, Entry: e, Err: err}
+		}
+		s := c.spanOf(e)
+		if i > 0 {
+			err := c.validateOrder(prev, s)
+			if err == nil && s.start.Equal(prev.end) && !c.KeepAdjacent {
+				err = ErrAdjacentEntries
+			}
+			if err != nil {
+				return &ValidationError{Index: i, Entry: e, Err: err}
+			}
+		}
+		prev = s
+	}
+	return nil
+}
+
+// spanAt returns the span of the entry at index i of the timeline, or a *ValidationError if that entry or the
+// one before it is invalid, or if they are not in order
+//
+// This allows operations that only visit some of the entries of a timeline (e.g. using a binary search) to
+// detect a timeline that is not normalized rather than panicking or making it worse.  Adjacent entries are not
+// treated as an error, since they do not affect such operations.
+func (c Config) spanAt(tl Timeline, i int) (span, error) {
+	e := tl[i]
+	if err := c.validateEntry(e); err != nil {
+		return span{}, &ValidationError{Index: i, Entry: e, Err: err}
+	}
+	s := c.spanOf(e)
+	if i > 0 {
+		prev := tl[i-1]
+		if err := c.validateEntry(prev); err != nil {
+			return span{}, &ValidationError{Index: i - 1, Entry: prev, Err: err}
+		}
+		if err := c.validateOrder(c.spanOf(prev), s); err != nil {
+			return span{}, &ValidationError{Index: i, Entry: e, Err: err}
+		}
+	}
+	return s, nil
+}
+
+// validateOrder checks that s, the span of an entry, is sorted after prev, the span of the preceding entry, and
+// does not overlap it
+func (c Config) validateOrder(prev, s span) error {
+	switch {
+	case s.start.Before(prev.start):
+		return ErrUnsortedTimeline
+	case s.start.Before(prev.end):
+		return ErrOverlappingEntries
+	}
+	return nil
+}
+
+// validEntries returns the entries that are valid, ignoring any others
+func (c Config) validEntries(entries []Entry) []Entry {
+	valid := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if c.validateEntry(e) == nil {
+			valid = append(valid, e)
+		}
+	}
+	return valid
+}
+
+// validateEntries checks that none of the entries are invalid
+func (c Config) validateEntries(entries []Entry) error {
+	for i, e := range entries {
+		if err := c.validateEntry(e); err != nil {
+			return &ValidationError{Index: i, Entry: e, Err: err}
+		}
+	}
+	return nil
+}
+
+// validateEntry checks that e is non-nil and does not end before it starts, which is only possible for Entry
+// implementations other than those returned by NewEntry()
+func (c Config) validateEntry(e Entry) error {
+	if e == nil {
+		return ErrNilEntry
+	}
+	if s := c.spanOf(e); !s.start.Before(s.end) {
+		return ErrInvalidTimelineOrder
+	}
+	return nil
+}
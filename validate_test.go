@@ -0,0 +1,177 @@
+package timeline_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+// reversedEntry is an Entry implementation that ends before it starts
+type reversedEntry struct{}
+
+func (reversedEntry) StartTime() time.Time {
+	return time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+func (reversedEntry) EndTime() (time.Time, bool) {
+	return time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), true
+}
+func (reversedEntry) Duration() time.Duration { return -1 }
+
+func TestValidate(t *testing.T) {
+	var (
+		y2000 = timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1))
+		y2001 = timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2002, time.January, 1))
+		y2002 = timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1))
+		long  = timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2002, time.June, 1))
+	)
+	cases := []struct {
+		name          string
+		value         timeline.Timeline
+		expected      error
+		expectedIndex int
+	}{
+		{"empty", timeline.Timeline{}, nil, 0},
+		{"normalized", timeline.Timeline{y2000, y2002}, nil, 0},
+		{"adjacent", timeline.Timeline{y2000, y2001}, timeline.ErrAdjacentEntries, 1},
+		{"nil entry", timeline.Timeline{y2000, nil}, timeline.ErrNilEntry, 1},
+		{"reversed entry", timeline.Timeline{reversedEntry{}}, timeline.ErrInvalidTimelineOrder, 0},
+		{"unsorted", timeline.Timeline{y2000, y2002, y2001}, timeline.ErrUnsortedTimeline, 2},
+		{"overlapping", timeline.Timeline{y2000, long, y2002}, timeline.ErrOverlappingEntries, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			err := tc.value.Validate()
+			if !errors.Is(err, tc.expected) || (err == nil) != (tc.expected == nil) {
+				tt.Fatalf("Expected %v, got %v", tc.expected, err)
+			}
+			var verr *timeline.ValidationError
+			if err != nil && (!errors.As(err, &verr) || verr.Index != tc.expectedIndex) {
+				tt.Errorf("Expected a *ValidationError at index %d, got %v", tc.expectedIndex, err)
+			}
+		})
+	}
+
+	if err := (timeline.Config{KeepAdjacent: true}).Validate(timeline.Timeline{y2000, y2001}); err != nil {
+		t.Errorf("Expected adjacent entries to be valid w/ KeepAdjacent, got %v", err)
+	}
+}
+
+func TestAddInvalid(t *testing.T) {
+	var (
+		y2000 = timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1))
+		y2002 = timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1))
+	)
+	tl := timeline.Timeline{y2000}
+	if _, err := tl.Add(y2002, nil); !errors.Is(err, timeline.ErrNilEntry) || len(tl) != 1 {
+		t.Errorf("Expected ErrNilEntry w/ the timeline unmodified, got %v and %s", err, printTimeline(tl))
+	}
+	if _, err := tl.Remove(reversedEntry{}); !errors.Is(err, timeline.ErrInvalidTimelineOrder) {
+		t.Errorf("Expected ErrInvalidTimelineOrder, got %v", err)
+	}
+
+	// a hand-built timeline where an entry that covers the new one follows one that is within it
+	tl = timeline.Timeline{
+		timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2000, time.July, 1)),
+		timeline.Must(timeline.ForDateRange(1990, time.January, 1, 2010, time.January, 1)),
+	}
+	malformed := tl.Clone()
+	if _, err := tl.Add(y2000); !errors.Is(err, timeline.ErrUnsortedTimeline) {
+		t.Errorf("Expected ErrUnsortedTimeline, got %v", err)
+	}
+	if !testIsSameTimeline(tl, malformed) {
+		t.Errorf("Expected the timeline to be unmodified, got %s", printTimeline(tl))
+	}
+	if _, err := tl.Add(y2000, y2002); !errors.Is(err, timeline.ErrUnsortedTimeline) {
+		t.Errorf("Expected ErrUnsortedTimeline, got %v", err)
+	}
+
+	if tl := timeline.New(y2002, nil, y2000); len(tl) != 2 {
+		t.Errorf("Expected New() to ignore nil entries, got %s", printTimeline(tl))
+	}
+}
+
+func TestMalformedTimeline(t *testing.T) {
+	var (
+		y2000 = timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1))
+		y2002 = timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1))
+		y2004 = timeline.Must(timeline.ForDateRange(2004, time.January, 1, 2005, time.January, 1))
+		long  = timeline.Must(timeline.ForDateRange(1990, time.January, 1, 2010, time.January, 1))
+	)
+	cases := []struct {
+		name     string
+		value    timeline.Timeline
+		entry    timeline.Entry
+		expected error
+		contains bool
+	}{
+		{"nil entry", timeline.Timeline{y2000, nil}, y2002, timeline.ErrNilEntry, false},
+		{"nil first entry", timeline.Timeline{nil, y2000}, y2002, timeline.ErrNilEntry, false},
+		{"reversed entry", timeline.Timeline{y2000, reversedEntry{}}, y2002, timeline.ErrInvalidTimelineOrder, false},
+		{"unsorted", timeline.Timeline{y2004, y2000}, y2002, timeline.ErrUnsortedTimeline, false},
+		{"overlapping", timeline.Timeline{long, y2002}, y2000, timeline.ErrOverlappingEntries, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			tl := tc.value.Clone()
+			if _, err := tl.Add(tc.entry); !errors.Is(err, tc.expected) {
+				tt.Errorf("Expected Add() to return %v, got %v", tc.expected, err)
+			}
+			// compare the printed timelines, since they may contain nil entries
+			if printTimeline(tl) != printTimeline(tc.value) {
+				tt.Errorf("Expected Add() to leave the timeline unmodified, got %s", printTimeline(tl))
+			}
+			if _, err := tl.Remove(tc.entry); !errors.Is(err, tc.expected) {
+				tt.Errorf("Expected Remove() to return %v, got %v", tc.expected, err)
+			}
+			if printTimeline(tl) != printTimeline(tc.value) {
+				tt.Errorf("Expected Remove() to leave the timeline unmodified, got %s", printTimeline(tl))
+			}
+			var verr *timeline.ValidationError
+			if _, err := tl.Add(tc.entry); !errors.As(err, &verr) {
+				tt.Errorf("Expected a *ValidationError, got %v", err)
+			}
+			if ok, _, _ := tl.Contains(tc.entry.StartTime()); ok != tc.contains {
+				tt.Errorf("Expected Contains() to return %v", tc.contains)
+			}
+			tl.Normalize()
+			if err := tl.Validate(); err != nil {
+				tt.Errorf("Expected Normalize() to return a valid timeline, got %v (%s)", err, printTimeline(tl))
+			}
+		})
+	}
+}
+
+func TestContainsNotNormalized(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2000, time.January, d, 0, 0, 0, 0, time.UTC) }
+	span := func(sd, ed int) timeline.Entry { return timeline.Must(timeline.NewEntry(day(sd), day(ed))) }
+	cases := []struct {
+		name     string
+		value    timeline.Timeline
+		expected map[int]bool
+	}{
+		{"adjacent", timeline.Timeline{span(1, 4), span(4, 7), span(9, 10)}, map[int]bool{2: true, 4: true, 5: true, 8: false, 9: true}},
+		{"keep adjacent", timeline.Config{KeepAdjacent: true}.New(span(1, 4), span(4, 7)), map[int]bool{2: true, 5: true, 8: false}},
+		{"unsorted", timeline.Timeline{span(9, 10), span(4, 7), span(1, 3)}, map[int]bool{2: true, 5: true, 8: false, 9: true}},
+		{"nil entry", timeline.Timeline{span(1, 3), nil, span(4, 7)}, map[int]bool{2: true, 3: true, 5: true, 8: false}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			for d, expected := range tc.expected {
+				if got, _, _ := tc.value.Contains(day(d)); got != expected {
+					tt.Errorf("Contains(%d): expected %v, got %v", d, expected, got)
+				}
+			}
+		})
+	}
+
+	// adjacent entries do not prevent entries from being added to or removed from a hand-built timeline
+	tl := timeline.Timeline{span(1, 4), span(4, 7)}
+	if _, err := tl.Add(span(8, 9)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := tl.Remove(span(2, 3)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package timeline
+
+import (
+	"sort"
+	"time"
+)
+
+// halfOpen is the Config used to interpret the segments of a ValueTimeline
+var halfOpen = Config{Boundary: BoundaryHalfOpen}
+
+// Segment represents a span of time within a ValueTimeline along with the value that applies to it
+type Segment[T any] struct {
+	Entry
+	Value T
+}
+
+// ChangePoint represents an instant at which the value of a ValueTimeline changes.  HasValue is false if no
+// value applies from that instant onwards (i.e. at the end of a segment that is not followed by another one).
+// At is the zero time for a segment that does not have a start.
+type ChangePoint[T any] struct {
+	At       time.Time
+	Value    T
+	HasValue bool
+}
+
+// MergeFunc defines a function that determines the value for the span of time where a newly added segment
+// overlaps an existing one
+type MergeFunc[T any] func(existing, added T) T
+
+// EqualFunc defines a function that reports whether two segment values are equal
+type EqualFunc[T any] func(a, b T) bool
+
+// Override is a MergeFunc that always uses the newly added value
+func Override[T any](_, added T) T {
+	return added
+}
+
+// ValueTimeline represents a piecewise-constant function of time, consisting of non-overlapping segments that
+// each carry a value of type T, sorted by the segments' start time.
+//
+// Unlike Timeline, the segments of a ValueTimeline are treated as half-open ranges (i.e. a segment does not
+// include its end time) so that exactly one value applies at the boundary between two adjacent segments.
+//
+// The zero value is an empty ValueTimeline that uses Override to resolve overlapping segments and never
+// combines adjacent segments.
+type ValueTimeline[T any] struct {
+	segments []Segment[T]
+	merge    MergeFunc[T]
+	equal    EqualFunc[T]
+}
+
+// NewValueTimeline returns a new, empty ValueTimeline.
+//
+// When a new segment overlaps existing ones, merge is called to determine the value for each overlapping span;
+// if merge is nil, Override is used.  If equal is non-nil, adjacent segments with equal values are combined
+// into a single segment.
+func NewValueTimeline[T any](merge MergeFunc[T], equal EqualFunc[T]) *ValueTimeline[T] {
+	return &ValueTimeline[T]{
+		merge: merge,
+		equal: equal,
+	}
+}
+
+// Add adds a new segment with the specified value to the timeline, splitting any existing segments that
+// overlap it
+func (vt *ValueTimeline[T]) Add(e Entry, v T) {
+	merge := vt.merge
+	if merge == nil {
+		merge = Override[T]
+	}
+	var (
+		ns  = halfOpen.spanOf(e)
+		cur = ns.start // start of the portion of the new segment that has not been added yet
+		res = make([]Segment[T], 0, len(vt.segments)+2)
+	)
+	for _, seg := range vt.segments {
+		s := halfOpen.spanOf(seg.Entry)
+		if !s.end.After(ns.start) || !s.start.Before(ns.end) {
+			// no overlap, add the remainder of the new segment first if this segment comes after it
+			if !s.start.Before(ns.end) && cur.Before(ns.end) {
+				res = append(res, newSegment(span{start: cur, end: ns.end}, v, e))
+				cur = ns.end
+			}
+			res = append(res, seg)
+			continue
+		}
+		// split the existing segment into the portions before, within and after the new one
+		if s.start.Before(ns.start) {
+			res = append(res, newSegment(span{start: s.start, end: ns.start}, seg.Value, seg.Entry))
+		}
+		if cur.Before(s.start) {
+			res = append(res, newSegment(span{start: cur, end: s.start}, v, e))
+		}
+		ov := span{start: laterOf(s.start, ns.start), end: earlierOf(s.end, ns.end)}
+		res = append(res, newSegment(ov, merge(seg.Value, v), seg.Entry, e))
+		if s.end.After(ns.end) {
+			res = append(res, newSegment(span{start: ns.end, end: s.end}, seg.Value, seg.Entry))
+		}
+		cur = ov.end
+	}
+	if cur.Before(ns.end) {
+		res = append(res, newSegment(span{start: cur, end: ns.end}, v, e))
+	}
+	vt.segments = vt.coalesce(res)
+}
+
+// coalesce combines adjacent segments with equal values, if an EqualFunc was provided
+func (vt *ValueTimeline[T]) coalesce(segs []Segment[T]) []Segment[T] {
+	if vt.equal == nil || len(segs) < 2 {
+		return segs
+	}
+	res := segs[:1]
+	for _, seg := range segs[1:] {
+		last := &res[len(res)-1]
+		ls, s := halfOpen.spanOf(last.Entry), halfOpen.spanOf(seg.Entry)
+		if ls.end.Equal(s.start) && vt.equal(last.Value, seg.Value) {
+			last.Entry = halfOpen.derive(span{start: ls.start, end: s.end}, last.Entry, seg.Entry)
+			continue
+		}
+		res = append(res, seg)
+	}
+	return res
+}
+
+// ValueAt returns the value that applies at the specified time, along with a boolean value indicating whether
+// or not any segment covers that time
+func (vt *ValueTimeline[T]) ValueAt(t time.Time) (T, bool) {
+	i := sort.Search(len(vt.segments), func(i int) bool {
+		return halfOpen.spanOf(vt.segments[i].Entry).end.After(t)
+	})
+	if i < len(vt.segments) && !halfOpen.spanOf(vt.segments[i].Entry).start.After(t) {
+		return vt.segments[i].Value, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Segments returns a copy of the segments in the timeline, sorted by start time
+func (vt *ValueTimeline[T]) Segments() []Segment[T] {
+	res := make([]Segment[T], len(vt.segments))
+	copy(res, vt.segments)
+	return res
+}
+
+// ChangePoints returns the instants at which the value of the timeline changes, in chronological order
+func (vt *ValueTimeline[T]) ChangePoints() []ChangePoint[T] {
+	var res []ChangePoint[T]
+	for i, seg := range vt.segments {
+		res = append(res, ChangePoint[T]{At: seg.StartTime(), Value: seg.Value, HasValue: true})
+		end, hasEnd := seg.EndTime()
+		if hasEnd && (i == len(vt.segments)-1 || !vt.segments[i+1].StartTime().Equal(end)) {
+			res = append(res, ChangePoint[T]{At: end})
+		}
+	}
+	return res
+}
+
+// Timeline returns a normalized Timeline covering every span of time that has a value
+func (vt *ValueTimeline[T]) Timeline() Timeline {
+	tl := make(Timeline, len(vt.segments))
+	for i, seg := range vt.segments {
+		tl[i] = seg.Entry
+	}
+	return halfOpen.Union(tl, nil)
+}
+
+// newSegment returns a new segment with the span s, whose entry is derived from the specified sources
+func newSegment[T any](s span, v T, sources ...Entry) Segment[T] {
+	return Segment[T]{Entry: halfOpen.derive(s, sources...), Value: v}
+}
@@ -0,0 +1,137 @@
+package timeline_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/code-willing/go-timeline"
+)
+
+func TestValueTimelineAdd(t *testing.T) {
+	sum := func(existing, added int) int { return existing + added }
+	eq := func(a, b int) bool { return a == b }
+	cases := []struct {
+		name     string
+		vt       *timeline.ValueTimeline[int]
+		segments []timeline.Segment[int]
+		expected []timeline.Segment[int]
+	}{
+		{
+			"non-overlapping",
+			timeline.NewValueTimeline[int](nil, nil),
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)), Value: 2},
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)), Value: 1},
+			},
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)), Value: 2},
+			},
+		},
+		{
+			"override splits existing",
+			timeline.NewValueTimeline[int](nil, nil),
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.FromStartDate(2000, time.January, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2002, time.January, 1)), Value: 2},
+			},
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2002, time.January, 1)), Value: 2},
+				{Entry: timeline.Must(timeline.FromStartDate(2002, time.January, 1)), Value: 1},
+			},
+		},
+		{
+			"merge across gap",
+			timeline.NewValueTimeline(sum, nil),
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2003, time.January, 1)), Value: 2},
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2002, time.June, 1)), Value: 10},
+			},
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.June, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.June, 1, 2001, time.January, 1)), Value: 11},
+				{Entry: timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2002, time.January, 1)), Value: 10},
+				{Entry: timeline.Must(timeline.ForDateRange(2002, time.January, 1, 2002, time.June, 1)), Value: 12},
+				{Entry: timeline.Must(timeline.ForDateRange(2002, time.June, 1, 2003, time.January, 1)), Value: 2},
+			},
+		},
+		{
+			"coalesce equal values",
+			timeline.NewValueTimeline(nil, eq),
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2001, time.January, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2002, time.January, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.FromStartDate(2002, time.January, 1)), Value: 2},
+			},
+			[]timeline.Segment[int]{
+				{Entry: timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2002, time.January, 1)), Value: 1},
+				{Entry: timeline.Must(timeline.FromStartDate(2002, time.January, 1)), Value: 2},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(tt *testing.T) {
+			for _, seg := range tc.segments {
+				tc.vt.Add(seg.Entry, seg.Value)
+			}
+			got := tc.vt.Segments()
+			if !testIsSameSegments(got, tc.expected) {
+				tt.Errorf("Expected:\n\t%v\nGot:\n\t%v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValueTimelineLookup(t *testing.T) {
+	var vt timeline.ValueTimeline[string]
+	vt.Add(timeline.Must(timeline.ForDateRange(2000, time.January, 1, 2000, time.March, 1)), "X")
+	vt.Add(timeline.Must(timeline.FromStartDate(2000, time.March, 1)), "Y")
+	vt.Add(timeline.Must(timeline.ForDateRange(2001, time.January, 1, 2001, time.February, 1)), "Z")
+
+	valueCases := []struct {
+		at       time.Time
+		expected string
+		ok       bool
+	}{
+		{time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC), "", false},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "X", true},
+		{time.Date(2000, time.March, 1, 0, 0, 0, 0, time.UTC), "Y", true},
+		{time.Date(2001, time.January, 15, 0, 0, 0, 0, time.UTC), "Z", true},
+		{time.Date(2050, time.January, 1, 0, 0, 0, 0, time.UTC), "Y", true},
+	}
+	for _, tc := range valueCases {
+		got, ok := vt.ValueAt(tc.at)
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf("ValueAt(%s): expected %q/%v, got %q/%v", tc.at, tc.expected, tc.ok, got, ok)
+		}
+	}
+
+	var points []string
+	for _, cp := range vt.ChangePoints() {
+		points = append(points, fmt.Sprintf("%s=%s/%v", cp.At.Format("2006-01-02"), cp.Value, cp.HasValue))
+	}
+	expected := "[2000-01-01=X/true 2000-03-01=Y/true 2001-01-01=Z/true 2001-02-01=Y/true]"
+	if got := fmt.Sprint(points); got != expected {
+		t.Errorf("ChangePoints(): expected %s, got %s", expected, got)
+	}
+
+	expectedTimeline := timeline.New(timeline.Must(timeline.FromStartDate(2000, time.January, 1)))
+	if got := vt.Timeline(); !testIsSameTimeline(got, expectedTimeline) {
+		t.Errorf("Timeline(): expected %s, got %s", printTimeline(expectedTimeline), printTimeline(got))
+	}
+}
+
+func testIsSameSegments[T comparable](s1, s2 []timeline.Segment[T]) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i, seg := range s1 {
+		if !testIsSameEntry(seg.Entry, s2[i].Entry) || seg.Value != s2[i].Value {
+			return false
+		}
+	}
+	return true
+}